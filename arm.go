@@ -0,0 +1,43 @@
+package dabug
+
+// Arm buffers up to maxLines of the most recent lines instead of writing
+// them, older lines are discarded. The buffered lines are only written when
+// Fire is called, ie: when an error is detected, giving full detail for
+// failing requests and no output for healthy ones. Arm(0) disarms and
+// discards the buffered lines.
+func Arm(maxLines int) {
+	defDabugger.Arm(maxLines)
+}
+
+func (d *Dabugger) Arm(maxLines int) {
+	d.linesMutex.Lock()
+	defer d.linesMutex.Unlock()
+
+	if maxLines <= 0 {
+		d.armed = nil
+		return
+	}
+	d.armed = RingSink(maxLines)
+}
+
+// Fire flushes the lines buffered since Arm as a section and keeps
+// buffering new lines.
+func Fire() {
+	defDabugger.Fire()
+}
+
+func (d *Dabugger) Fire() {
+	d.linesMutex.Lock()
+	if d.armed == nil {
+		d.linesMutex.Unlock()
+		return
+	}
+	armed := d.armed.Lines()
+	for i := range armed {
+		d.lines = append(d.lines, &armed[i])
+	}
+	d.armed = RingSink(d.armed.max)
+	d.linesMutex.Unlock()
+
+	d.Flush()
+}
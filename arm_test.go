@@ -0,0 +1,46 @@
+package dabug
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArmFire(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.Arm(3)
+
+	for i := 0; i < 5; i++ {
+		d.Msg("healthy %d", i)
+	}
+	assert.Empty(t, rec.Lines())
+
+	d.Fire()
+	lines := rec.Lines()
+	require.Len(t, lines, 3)
+	for i, l := range lines {
+		assert.Equal(t, fmt.Sprintf("healthy %d", i+2), l.Msg)
+	}
+
+	// firing again only writes the lines since the last Fire
+	rec.Reset()
+	d.Msg("failing")
+	d.Fire()
+	d.Fire()
+	lines = rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Equal(t, "failing", lines[0].Msg)
+
+	rec.Reset()
+	d.Msg("discarded")
+	d.Arm(0)
+	d.Fire()
+	d.Msg("written")
+	lines = rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Equal(t, "written", lines[0].Msg)
+}
@@ -0,0 +1,36 @@
+package dabug
+
+import "fmt"
+
+// Assert appends an error line with the message and the stack of the caller
+// if cond is false, and reports cond. If AssertPanics is enabled pending
+// lines are flushed and Assert panics with the message, ie:
+//
+//	dabug.Assert(len(queue) <= limit, "queue over limit: %d", len(queue))
+func Assert(cond bool, format string, v ...any) bool {
+	return defDabugger.Assert(cond, format, v...)
+}
+
+func (d *Dabugger) Assert(cond bool, format string, v ...any) bool {
+	if cond {
+		return true
+	}
+
+	msg := "assertion failed: " + fmt.Sprintf(format, d.humanArgs(v)...)
+	d.appendLevel(LevelErr, d.truncate(msg+"\n"+callerStack()))
+
+	if d.assertPanics {
+		d.Flush()
+		panic(msg)
+	}
+	return false
+}
+
+// AssertPanics makes Assert panic when the condition is false.
+func AssertPanics(enabled bool) {
+	defDabugger.AssertPanics(enabled)
+}
+
+func (d *Dabugger) AssertPanics(enabled bool) {
+	d.assertPanics = enabled
+}
@@ -0,0 +1,48 @@
+package dabug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssert(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	assert.True(t, d.Assert(true, "unused"))
+	assert.False(t, d.Assert(1 > 2, "%d > %d", 1, 2))
+
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Equal(t, LevelErr, lines[0].Level)
+	assert.Equal(t, "assert_test.go", lines[0].File)
+
+	stack := strings.Split(lines[0].Msg, "\n")
+	assert.Equal(t, "assertion failed: 1 > 2", stack[0])
+	assert.Equal(t, "  github.com/dcaravel/dabug.TestAssert", stack[1])
+	assert.Contains(t, stack[2], "assert_test.go:")
+}
+
+func TestAssertPanics(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.AutoFlush(false)
+	d.AssertPanics(true)
+
+	d.Msg("before")
+	assert.NotPanics(t, func() { d.Assert(true, "ok") })
+	assert.PanicsWithValue(t, "assertion failed: bad state", func() {
+		d.Assert(false, "bad %s", "state")
+	})
+
+	// pending lines are flushed before panicking
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "before", lines[0].Msg)
+	assert.True(t, strings.HasPrefix(lines[1].Msg, "assertion failed: bad state\n"))
+}
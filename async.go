@@ -0,0 +1,128 @@
+package dabug
+
+import (
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy decides what happens when a bounded queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for room in the queue.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest queued output to make room.
+	OverflowDropOldest
+	// OverflowDropNewest discards the output being written.
+	OverflowDropNewest
+)
+
+// AsyncWriter queues output for a background goroutine so that writing to a
+// slow writer does not stall the code being debugged. It writes bytes only,
+// LineWriters wrapped by it receive rendered text.
+type AsyncWriter struct {
+	w      io.Writer
+	policy OverflowPolicy
+	queue  chan []byte
+	done   chan struct{}
+
+	mu      sync.RWMutex
+	closed  bool
+	dropped atomic.Uint64
+	errs    atomic.Uint64
+}
+
+// Async wraps w with a queue of up to size pending writes, use it with
+// Writer or AddWriter and Close it to drain the queue.
+func Async(w io.Writer, size int, policy OverflowPolicy) *AsyncWriter {
+	a := &AsyncWriter{
+		w:      w,
+		policy: policy,
+		queue:  make(chan []byte, max(size, 1)),
+		done:   make(chan struct{}),
+	}
+	go a.run()
+
+	return a
+}
+
+func (a *AsyncWriter) run() {
+	defer close(a.done)
+
+	for p := range a.queue {
+		if _, err := a.w.Write(p); err != nil {
+			a.errs.Add(1)
+		}
+	}
+}
+
+func (a *AsyncWriter) Write(p []byte) (int, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.closed {
+		return 0, os.ErrClosed
+	}
+
+	b := append([]byte(nil), p...)
+
+	switch a.policy {
+	case OverflowDropNewest:
+		select {
+		case a.queue <- b:
+		default:
+			a.dropped.Add(1)
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case a.queue <- b:
+				return len(p), nil
+			default:
+			}
+
+			select {
+			case <-a.queue:
+				a.dropped.Add(1)
+			default:
+			}
+		}
+	default:
+		a.queue <- b
+	}
+
+	return len(p), nil
+}
+
+// Dropped returns the number of writes discarded due to the overflow
+// policy.
+func (a *AsyncWriter) Dropped() uint64 {
+	return a.dropped.Load()
+}
+
+// Errors returns the number of writes that failed on the wrapped writer.
+func (a *AsyncWriter) Errors() uint64 {
+	return a.errs.Load()
+}
+
+// Close writes any queued output then closes the wrapped writer if it is
+// an io.Closer.
+func (a *AsyncWriter) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	close(a.queue)
+	a.mu.Unlock()
+
+	<-a.done
+
+	if c, ok := a.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package dabug
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gateWriter blocks writes until release is closed.
+type gateWriter struct {
+	release chan struct{}
+	mu      sync.Mutex
+	writes  []string
+}
+
+func (g *gateWriter) Write(p []byte) (int, error) {
+	<-g.release
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.writes = append(g.writes, string(p))
+	return len(p), nil
+}
+
+func TestAsyncBlock(t *testing.T) {
+	sb := &strings.Builder{}
+	a := Async(sb, 2, OverflowBlock)
+
+	d := New()
+	d.Writer(a)
+	for i := 0; i < 20; i++ {
+		d.Msg("msg %d", i)
+	}
+	require.NoError(t, a.Close())
+
+	assert.Equal(t, 20, strings.Count(sb.String(), "\n"))
+	assert.Zero(t, a.Dropped())
+
+	_, err := a.Write([]byte("closed"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+	assert.NoError(t, a.Close())
+}
+
+func TestAsyncDrop(t *testing.T) {
+	for _, policy := range []OverflowPolicy{OverflowDropOldest, OverflowDropNewest} {
+		g := &gateWriter{release: make(chan struct{})}
+		a := Async(g, 2, policy)
+
+		// the first write may be in flight in the background goroutine
+		// blocked on the gate, so write enough to overflow regardless
+		for i := 0; i < 10; i++ {
+			_, err := a.Write([]byte(fmt.Sprint(i)))
+			require.NoError(t, err)
+		}
+		close(g.release)
+		require.NoError(t, a.Close())
+
+		assert.Equal(t, uint64(10-len(g.writes)), a.Dropped())
+		assert.LessOrEqual(t, len(g.writes), 3)
+		if policy == OverflowDropOldest {
+			assert.Equal(t, "9", g.writes[len(g.writes)-1])
+		} else {
+			assert.NotContains(t, g.writes, "9")
+		}
+	}
+}
+
+func TestAsyncErrors(t *testing.T) {
+	a := Async(failWriter{}, 1, OverflowBlock)
+	a.Write([]byte("x"))
+	require.NoError(t, a.Close())
+	assert.Equal(t, uint64(1), a.Errors())
+}
@@ -0,0 +1,38 @@
+package dabug
+
+import (
+	"fmt"
+	"os"
+)
+
+// CaptureToTempFile redirects all output to a uniquely named temp file and
+// returns a func that flushes and closes the file, restores the previous
+// writers, then prints the path of the file to stderr, ie:
+//
+//	func main() {
+//		defer dabug.CaptureToTempFile()()
+//		...
+//	}
+//
+// If the temp file cannot be created output is left unchanged.
+func CaptureToTempFile() func() {
+	return defDabugger.CaptureToTempFile()
+}
+
+func (d *Dabugger) CaptureToTempFile() func() {
+	f, err := os.CreateTemp("", "dabug-*.log")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%sunable to capture to temp file: %v\n", d.linePrefix, err)
+		return func() {}
+	}
+
+	prev := d.writers
+	d.Writer(f)
+
+	return func() {
+		d.Flush()
+		d.writers = prev
+		f.Close()
+		fmt.Fprintf(os.Stderr, "%soutput captured to %s\n", d.linePrefix, f.Name())
+	}
+}
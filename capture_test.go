@@ -0,0 +1,48 @@
+package dabug
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureToTempFile(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	d := New()
+	done := d.CaptureToTempFile()
+	d.Msg("captured")
+	d.AutoFlush(false)
+	d.Msg("buffered")
+
+	require.Len(t, d.writers, 1)
+	f, ok := d.writers[0].(*os.File)
+	require.True(t, ok)
+	done()
+
+	b, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "captured")
+	assert.Contains(t, string(b), "buffered")
+}
+
+func TestCaptureToTempFileRestoresWriters(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.CaptureToTempFile()()
+
+	var errs []error
+	d.OnWriteError(func(err error) { errs = append(errs, err) })
+	d.Msg("after")
+	d.Flush()
+
+	assert.Empty(t, errs)
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Equal(t, "after", lines[0].Msg)
+}
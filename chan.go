@@ -0,0 +1,97 @@
+package dabug
+
+import (
+	"fmt"
+	"time"
+)
+
+// Chan wraps a channel and writes a line for every send, receive, and close
+// through it. Operations that block write a line when they start blocking
+// and another with the time blocked once they complete, so a deadlock shows
+// up as a blocked line without a matching completion, ie:
+//
+//	jobs := dabug.WrapChan("jobs", make(chan int))
+//	go func() { jobs.Send(1) }()
+//	v, ok := jobs.Recv()
+type Chan[T any] struct {
+	C    chan T
+	Name string
+	// Dabugger writes the lines, the default Dabugger is used if nil
+	Dabugger *Dabugger
+}
+
+// WrapChan returns a Chan for c that writes to the default Dabugger.
+func WrapChan[T any](name string, c chan T) *Chan[T] {
+	return &Chan[T]{C: c, Name: name}
+}
+
+func (c *Chan[T]) dabugger() *Dabugger {
+	if c.Dabugger != nil {
+		return c.Dabugger
+	}
+	return defDabugger
+}
+
+// Send sends v on the channel.
+func (c *Chan[T]) Send(v T) {
+	d := c.dabugger()
+	if !d.enabled() {
+		c.C <- v
+		return
+	}
+
+	val := d.objStr(v)
+	select {
+	case c.C <- v:
+		d.appendMsg(fmt.Sprintf("chan %s <- %s (%s)", c.Name, val, c.lenStr()))
+		return
+	default:
+	}
+
+	d.appendMsg(fmt.Sprintf("chan %s <- %s blocked (%s)", c.Name, val, c.lenStr()))
+	start := time.Now()
+	c.C <- v
+	d.appendMsg(fmt.Sprintf("chan %s <- %s after %s", c.Name, val, fmtDuration(time.Since(start))))
+}
+
+// Recv receives from the channel, ok is false if the channel is closed.
+func (c *Chan[T]) Recv() (v T, ok bool) {
+	d := c.dabugger()
+	if !d.enabled() {
+		v, ok = <-c.C
+		return v, ok
+	}
+
+	select {
+	case v, ok = <-c.C:
+		d.appendMsg(c.recvStr(d, v, ok, ""))
+		return v, ok
+	default:
+	}
+
+	d.appendMsg(fmt.Sprintf("chan %s -> blocked (%s)", c.Name, c.lenStr()))
+	start := time.Now()
+	v, ok = <-c.C
+	d.appendMsg(c.recvStr(d, v, ok, " after "+fmtDuration(time.Since(start))))
+	return v, ok
+}
+
+// Close closes the channel.
+func (c *Chan[T]) Close() {
+	d := c.dabugger()
+	if d.enabled() {
+		d.appendMsg(fmt.Sprintf("chan %s closed (%s)", c.Name, c.lenStr()))
+	}
+	close(c.C)
+}
+
+func (c *Chan[T]) recvStr(d *Dabugger, v T, ok bool, waited string) string {
+	if !ok {
+		return fmt.Sprintf("chan %s -> closed%s", c.Name, waited)
+	}
+	return fmt.Sprintf("chan %s -> %s%s", c.Name, d.objStr(v), waited)
+}
+
+func (c *Chan[T]) lenStr() string {
+	return fmt.Sprintf("len %d/%d", len(c.C), cap(c.C))
+}
@@ -0,0 +1,68 @@
+package dabug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChan(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	c := &Chan[int]{C: make(chan int, 1), Name: "jobs", Dabugger: d}
+	c.Send(1)
+	v, ok := c.Recv()
+	assert.Equal(t, 1, v)
+	assert.True(t, ok)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		c.C <- 2
+	}()
+	v, _ = c.Recv()
+	assert.Equal(t, 2, v)
+
+	c.Close()
+	_, ok = c.Recv()
+	assert.False(t, ok)
+
+	msgs := []string{}
+	for _, l := range rec.Lines() {
+		msgs = append(msgs, l.Msg)
+	}
+	require.Len(t, msgs, 6)
+	assert.Equal(t, "chan jobs <- 1 (len 1/1)", msgs[0])
+	assert.Equal(t, "chan jobs -> 1", msgs[1])
+	assert.Equal(t, "chan jobs -> blocked (len 0/1)", msgs[2])
+	assert.Regexp(t, `^chan jobs -> 2 after \S+$`, msgs[3])
+	assert.Equal(t, "chan jobs closed (len 0/1)", msgs[4])
+	assert.Equal(t, "chan jobs -> closed", msgs[5])
+	assert.Equal(t, "chan_test.go", rec.Lines()[0].File)
+}
+
+func TestChanSendBlocked(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	c := WrapChan("unbuffered", make(chan string))
+	assert.Same(t, defDabugger, c.dabugger())
+	c.Dabugger = d
+	done := make(chan struct{})
+	go func() {
+		c.Send("a")
+		close(done)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, "a", <-c.C)
+	<-done
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, `chan unbuffered <- "a" blocked (len 0/0)`, lines[0].Msg)
+	assert.Regexp(t, `^chan unbuffered <- "a" after \S+$`, lines[1].Msg)
+}
@@ -0,0 +1,47 @@
+package dabug
+
+import "errors"
+
+// ErrChanFull is returned by a ChanWriter when a line is dropped because the
+// channel is full.
+var ErrChanFull = errors.New("dabug: channel full")
+
+// ChanWriter is a LineWriter that sends lines to a channel.
+type ChanWriter struct {
+	ch     chan<- Line
+	policy OverflowPolicy
+}
+
+// ChanSink creates a writer that sends every line to ch, use it with Writer
+// or AddWriter. Dropped lines are reported as ErrChanFull write errors, see
+// OnWriteError. OverflowDropOldest cannot receive from a send-only channel
+// so it drops the newest line like OverflowDropNewest.
+func ChanSink(ch chan<- Line, policy OverflowPolicy) *ChanWriter {
+	return &ChanWriter{
+		ch:     ch,
+		policy: policy,
+	}
+}
+
+func (c *ChanWriter) WriteLine(l Line) error {
+	if c.policy == OverflowBlock {
+		c.ch <- l
+		return nil
+	}
+
+	select {
+	case c.ch <- l:
+		return nil
+	default:
+		return ErrChanFull
+	}
+}
+
+func (c *ChanWriter) Write(p []byte) (int, error) {
+	for _, l := range textLines(p) {
+		if err := c.WriteLine(l); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,41 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChanSink(t *testing.T) {
+	ch := make(chan Line, 2)
+	d := New()
+	d.Writer(ChanSink(ch, OverflowDropNewest))
+
+	var errs []error
+	d.OnWriteError(func(err error) { errs = append(errs, err) })
+
+	d.AddContext("k", "v")
+	d.Msg("one")
+	d.Warn("two")
+	d.Msg("three")
+
+	require.Len(t, ch, 2)
+	l := <-ch
+	assert.Equal(t, "one", l.Msg)
+	assert.Equal(t, "chansink_test.go", l.File)
+	assert.Equal(t, Fields{{"k", "v"}}, l.Context)
+	assert.Equal(t, LevelWarn, (<-ch).Level)
+
+	assert.Equal(t, []error{ErrChanFull}, errs)
+	assert.Equal(t, uint64(1), d.Dropped())
+}
+
+func TestChanSinkBlock(t *testing.T) {
+	ch := make(chan Line)
+	d := New()
+	d.Writer(ChanSink(ch, OverflowBlock))
+
+	go d.Msg("blocking")
+	assert.Equal(t, "blocking", (<-ch).Msg)
+}
@@ -0,0 +1,61 @@
+package dabug
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// CheckErr appends an error line with err if it is not nil and reports
+// whether err was nil, replacing the if err != nil { dabug.Msg(...) }
+// pattern, ie:
+//
+//	if !dabug.CheckErr(err) {
+//		return err
+//	}
+func CheckErr(err error) bool {
+	return defDabugger.CheckErr(err)
+}
+
+func (d *Dabugger) CheckErr(err error) bool {
+	if err == nil {
+		return true
+	}
+
+	msg := "err: " + err.Error()
+	if d.errStack {
+		msg += "\n" + callerStack()
+	}
+	d.appendLevel(LevelErr, d.truncate(msg))
+
+	return false
+}
+
+// ErrStack includes the stack of the caller in CheckErr lines.
+func ErrStack(enabled bool) {
+	defDabugger.ErrStack(enabled)
+}
+
+func (d *Dabugger) ErrStack(enabled bool) {
+	d.errStack = enabled
+}
+
+// callerStack renders the stack of the code that called into dabug, one
+// function and location per line.
+func callerStack() string {
+	var pcs [64]uintptr
+	n := runtime.Callers(2, pcs[:])
+	fs := runtime.CallersFrames(pcs[:n])
+
+	var frames []string
+	for more := true; more; {
+		var f runtime.Frame
+		f, more = fs.Next()
+		if internalFrame(f) {
+			continue
+		}
+		frames = append(frames, fmt.Sprintf("%s%s\n%s%s%s:%d", indent, f.Function, indent, indent, f.File, f.Line))
+	}
+
+	return strings.Join(frames, "\n")
+}
@@ -0,0 +1,34 @@
+package dabug
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckErr(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	assert.True(t, d.CheckErr(nil))
+	assert.False(t, d.CheckErr(errors.New("boom")))
+
+	d.ErrStack(true)
+	assert.False(t, d.CheckErr(errors.New("stacked")))
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "err: boom", lines[0].Msg)
+	assert.Equal(t, LevelErr, lines[0].Level)
+	assert.Equal(t, "checkerr_test.go", lines[0].File)
+
+	stack := strings.Split(lines[1].Msg, "\n")
+	assert.Equal(t, "err: stacked", stack[0])
+	assert.Equal(t, "  github.com/dcaravel/dabug.TestCheckErr", stack[1])
+	assert.Contains(t, stack[2], "checkerr_test.go:")
+	assert.NotContains(t, lines[1].Msg, "checkerr.go")
+}
@@ -0,0 +1,31 @@
+package dabug
+
+import "fmt"
+
+// collapseLines replaces runs of consecutive lines with the same source,
+// level, and message by the first line of the run annotated with the run
+// length, ie: `retrying ×120`.
+func collapseLines(lines []*Line) []*Line {
+	var collapsed []*Line
+	for i := 0; i < len(lines); {
+		l := lines[i]
+		n := 1
+		for i+n < len(lines) && sameLine(l, lines[i+n]) {
+			n++
+		}
+		i += n
+
+		if n > 1 {
+			c := *l
+			c.Msg = fmt.Sprintf("%s ×%d", l.Msg, n)
+			c.repeats = n
+			l = &c
+		}
+		collapsed = append(collapsed, l)
+	}
+	return collapsed
+}
+
+func sameLine(a, b *Line) bool {
+	return a.Source == b.Source && a.Level == b.Level && a.Msg == b.Msg
+}
@@ -0,0 +1,48 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollapseLines(t *testing.T) {
+	a := Source{File: "a.go", Line: 1}
+	b := Source{File: "a.go", Line: 2}
+	lines := []*Line{
+		{Source: a, Msg: "retry"},
+		{Source: a, Msg: "retry"},
+		{Source: a, Msg: "retry"},
+		{Source: b, Msg: "retry"},
+		{Source: a, Msg: "retry", Level: LevelWarn},
+		{Source: a, Msg: "retry", Level: LevelWarn},
+		{Source: a, Msg: "done"},
+	}
+
+	var msgs []string
+	for _, l := range collapseLines(lines) {
+		msgs = append(msgs, l.Msg)
+	}
+	assert.Equal(t, []string{"retry ×3", "retry", "retry ×2", "done"}, msgs)
+	assert.Equal(t, "retry", lines[0].Msg)
+	assert.Empty(t, collapseLines(nil))
+}
+
+func TestFlushCollapses(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.AutoFlush(false)
+
+	for i := 0; i < 100; i++ {
+		d.Msg("retrying")
+	}
+	d.Msg("done")
+	d.Flush()
+
+	lines := rec.Lines()
+	if assert.Len(t, lines, 2) {
+		assert.Equal(t, "retrying ×100", lines[0].Msg)
+		assert.Equal(t, "done", lines[1].Msg)
+	}
+}
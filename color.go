@@ -0,0 +1,56 @@
+package dabug
+
+import (
+	"io"
+	"os"
+)
+
+const (
+	colorReset   = "\x1b[0m"
+	colorSource  = "\x1b[36m"
+	colorContext = "\x1b[33m"
+	colorMsg     = "\x1b[32m"
+	colorOk      = "\x1b[1;32m"
+	colorWarn    = "\x1b[1;33m"
+	colorErr     = "\x1b[1;31m"
+)
+
+// Color forces colored output on or off, by default color is used when
+// writing to a terminal and the NO_COLOR environment variable is not set.
+func Color(enabled bool) {
+	defDabugger.Color(enabled)
+}
+
+func (d *Dabugger) Color(enabled bool) {
+	d.color = &enabled
+}
+
+func (d *Dabugger) useColor(w io.Writer) bool {
+	if d.color != nil {
+		return *d.color
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	return isTerminal(w)
+}
+
+func colorize(color, s string) string {
+	return color + s + colorReset
+}
+
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
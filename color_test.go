@@ -0,0 +1,57 @@
+package dabug
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColor(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.AutoFlush(false)
+	d.Msg("msg")
+	d.AddContext("k", "v")
+	d.Msg("msg2")
+
+	d.Color(true)
+	d.Flush()
+
+	parts := strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 5)
+	assert.Contains(t, parts[1], colorSource+"color_test.go:")
+	assert.Contains(t, parts[1], colorize(colorMsg, "msg"))
+	assert.Contains(t, parts[2], colorize(colorContext, "(k:v)"))
+
+	// alignment must ignore the escape codes
+	stripped := func(s string) int {
+		for _, c := range []string{colorSource, colorContext, colorMsg, colorReset} {
+			s = strings.ReplaceAll(s, c, "")
+		}
+		return strings.Index(s, "- msg")
+	}
+	assert.Equal(t, stripped(parts[1]), stripped(parts[2]))
+
+	sb.Reset()
+	d.Color(false)
+	d.Msg("msg")
+	d.Flush()
+	assert.NotContains(t, sb.String(), "\x1b[")
+}
+
+func TestColorDetect(t *testing.T) {
+	d := New()
+	assert.False(t, d.useColor(&strings.Builder{}))
+
+	f, err := os.CreateTemp(t.TempDir(), "")
+	require.NoError(t, err)
+	defer f.Close()
+	assert.False(t, d.useColor(f))
+
+	d.Color(true)
+	assert.True(t, d.useColor(f))
+}
@@ -0,0 +1,98 @@
+package dabug
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// AlignColumns aligns the source, context, and message of flushed lines as
+// independent columns instead of padding the combined line header.
+func AlignColumns(enabled bool) {
+	defDabugger.AlignColumns(enabled)
+}
+
+func (d *Dabugger) AlignColumns(enabled bool) {
+	d.alignColumns = enabled
+}
+
+// columnsStr renders the pending lines as a section using a tabwriter, must
+// be called with linesMutex held.
+func (d *Dabugger) columnsStr(opts textOpts) string {
+	hasCtx := false
+	srcWidth, ctxWidth := 0, 0
+	for _, l := range d.lines {
+		hasCtx = hasCtx || len(l.Context) > 0
+		srcWidth = max(srcWidth, len(l.Prefix)+len(sourceStr(l)))
+		if len(l.Context) > 0 {
+			ctxWidth = max(ctxWidth, len(fmt.Sprintf("(%s)", l.Context)))
+		}
+	}
+
+	// the column the message starts at, used for wrapping
+	msgCol := srcWidth + 1 + len("- ")
+	if hasCtx {
+		msgCol += ctxWidth + 1
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("%s%s\n", d.linePrefix, delimStr(d.sectionBeg, d.sectionName)))
+
+	tw := tabwriter.NewWriter(&sb, 0, 0, 1, ' ', 0)
+	for _, l := range d.lines {
+		for _, row := range columnRows(l, hasCtx, msgCol, opts) {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+	}
+	tw.Flush()
+
+	sb.WriteString(d.sectionFooter())
+
+	return sb.String()
+}
+
+// columnRows splits l into one row of cells per message line. When color is
+// enabled every cell in a column is wrapped in the same escape codes so that
+// the byte widths measured by the tabwriter stay consistent.
+func columnRows(l *Line, hasCtx bool, msgCol int, opts textOpts) [][]string {
+	cell := func(c, s string) string {
+		if opts.color {
+			return colorize(c, s)
+		}
+		return s
+	}
+
+	src := sourceStr(l)
+	ctx := ""
+	if len(l.Context) > 0 {
+		ctx = fmt.Sprintf("(%s)", l.Context)
+	}
+
+	msgs := opts.wrap(l, msgCol)
+	rows := make([][]string, 0, len(msgs))
+	for i, m := range msgs {
+		var row []string
+		marker := "- " + levelStr(l, opts.color)
+		if i == 0 {
+			row = append(row, l.Prefix+cell(colorSource, src))
+			if hasCtx {
+				row = append(row, cell(colorContext, ctx))
+			}
+		} else {
+			marker = "| "
+			row = append(row, l.Prefix+cell(colorSource, ""))
+			if hasCtx {
+				row = append(row, cell(colorContext, ""))
+			}
+		}
+
+		if l.Msg == "" {
+			row = append(row, "")
+		} else {
+			row = append(row, marker+cell(colorMsg, m))
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
@@ -0,0 +1,52 @@
+package dabug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlignColumns(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.LinePrefix("")
+	d.AutoFlush(false)
+	d.AlignColumns(true)
+
+	d.Msg("one")
+	d.AddContext("key", "a long context value")
+	d.Msg("two\nthree")
+	d.RemoveAllContext()
+	d.AddContext("k", "v")
+	d.Msg("four")
+	d.Flush()
+
+	parts := strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 7)
+
+	ctxCol := strings.Index(parts[2], "(key:")
+	msgCol := strings.Index(parts[1], "- one")
+	assert.Greater(t, ctxCol, 0)
+	assert.Equal(t, ctxCol, strings.Index(parts[4], "(k:v)"))
+	assert.Equal(t, msgCol, strings.Index(parts[2], "- two"))
+	assert.Equal(t, msgCol, strings.Index(parts[3], "| three"))
+	assert.Equal(t, msgCol, strings.Index(parts[4], "- four"))
+
+	sb.Reset()
+	d.Color(true)
+	d.Msg("five")
+	d.RemoveAllContext()
+	d.Msg("six")
+	d.Flush()
+
+	out := sb.String()
+	for _, c := range []string{colorSource, colorContext, colorMsg, colorReset} {
+		out = strings.ReplaceAll(out, c, "")
+	}
+	parts = strings.Split(out, "\n")
+	require.Len(t, parts, 5)
+	assert.Equal(t, strings.Index(parts[1], "- five"), strings.Index(parts[2], "- six"))
+}
@@ -0,0 +1,26 @@
+package dabug
+
+// MsgIf appends a message only if cond is true, the message is not
+// formatted otherwise.
+func MsgIf(cond bool, format string, v ...any) {
+	defDabugger.MsgIf(cond, format, v...)
+}
+
+func (d *Dabugger) MsgIf(cond bool, format string, v ...any) {
+	if !cond {
+		return
+	}
+	d.Msg(format, v...)
+}
+
+// ObjsIf prints things as with Objs only if cond is true.
+func ObjsIf(cond bool, things ...any) {
+	defDabugger.ObjsIf(cond, things...)
+}
+
+func (d *Dabugger) ObjsIf(cond bool, things ...any) {
+	if !cond {
+		return
+	}
+	d.Objs(things...)
+}
@@ -0,0 +1,33 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type panicStringer struct{}
+
+func (panicStringer) String() string {
+	panic("formatted")
+}
+
+func TestMsgIfObjsIf(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	assert.NotPanics(t, func() {
+		d.MsgIf(false, "%v", panicStringer{})
+		d.ObjsIf(false, panicStringer{})
+	})
+	d.MsgIf(true, "n=%d", 1)
+	d.ObjsIf(true, 2)
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "n=1", lines[0].Msg)
+	assert.Equal(t, "[0] 2", lines[1].Msg)
+	assert.Equal(t, "cond_test.go", lines[1].File)
+}
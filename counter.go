@@ -0,0 +1,77 @@
+package dabug
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// counter is a named counter incremented by Count and CountN.
+type counter struct {
+	total int
+	sites []Source
+}
+
+// Count increments the counter name by one, counters are written as a table
+// by Report and Flush instead of a line per call.
+func Count(name string) {
+	defDabugger.CountN(name, 1)
+}
+
+func (d *Dabugger) Count(name string) {
+	d.CountN(name, 1)
+}
+
+// CountN increments the counter name by n.
+func CountN(name string, n int) {
+	defDabugger.CountN(name, n)
+}
+
+func (d *Dabugger) CountN(name string, n int) {
+	if !d.enabled() {
+		return
+	}
+
+	src := d.getSource()
+
+	d.statsMutex.Lock()
+	defer d.statsMutex.Unlock()
+
+	if d.counters == nil {
+		d.counters = map[string]*counter{}
+	}
+	c, ok := d.counters[name]
+	if !ok {
+		c = &counter{}
+		d.counters[name] = c
+	}
+
+	c.total += n
+	if !slices.Contains(c.sites, src) {
+		c.sites = append(c.sites, src)
+	}
+}
+
+func countersStr(counters map[string]*counter) string {
+	names := make([]string, 0, len(counters))
+	for name := range counters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	sb := strings.Builder{}
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "counter\ttotal\tcall sites")
+	for _, name := range names {
+		c := counters[name]
+		sites := make([]string, len(c.sites))
+		for i, s := range c.sites {
+			sites[i] = s.String()
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", name, c.total, strings.Join(sites, ", "))
+	}
+	tw.Flush()
+
+	return "counters\n" + strings.TrimSuffix(sb.String(), "\n")
+}
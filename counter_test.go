@@ -0,0 +1,39 @@
+package dabug
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCount(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.AutoFlush(false)
+
+	for i := 0; i < 3; i++ {
+		d.Count("loop")
+	}
+	d.CountN("items", 10)
+	d.CountN("items", 5)
+	_, _, line, _ := runtime.Caller(0)
+	d.Count("loop")
+	assert.Empty(t, rec.Lines())
+
+	d.Flush()
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+
+	site := func(l int) string { return fmt.Sprintf("counter_test.go:%d", l) }
+	assert.Equal(t, "counters\n"+
+		"counter  total  call sites\n"+
+		"items    15     "+site(line-2)+", "+site(line-1)+"\n"+
+		"loop     4      "+site(line-4)+", "+site(line+1), lines[0].Msg)
+
+	d.Flush()
+	assert.Len(t, rec.Lines(), 1)
+}
@@ -0,0 +1,44 @@
+package dabug
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{"timestamp", "file", "line", "function", "context", "message"}
+
+// WriteCSV writes the retained lines followed by the lines waiting to be
+// flushed as CSV, see Retain.
+func WriteCSV(w io.Writer) error {
+	return defDabugger.WriteCSV(w)
+}
+
+func (d *Dabugger) WriteCSV(w io.Writer) error {
+	d.linesMutex.Lock()
+	lines := append(append([]*Line{}, d.retained...), d.lines...)
+	d.linesMutex.Unlock()
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, l := range lines {
+		record := []string{
+			l.Time.Format(time.RFC3339Nano),
+			l.File,
+			strconv.Itoa(l.Line),
+			l.Function,
+			l.Context.String(),
+			l.Msg,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,48 @@
+package dabug
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCSV(t *testing.T) {
+	d := New()
+	d.Writer(io.Discard)
+	d.Retain(2)
+	d.Msg("old")
+	d.Msg("retained")
+	d.AddContext("k", "v")
+	d.Msg("retained, with comma")
+
+	d.AutoFlush(false)
+	d.Msg("buffered")
+
+	sb := &strings.Builder{}
+	require.NoError(t, d.WriteCSV(sb))
+
+	records, err := csv.NewReader(strings.NewReader(sb.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, csvHeader, records[0])
+
+	msgs := []string{"retained", "retained, with comma", "buffered"}
+	for i, r := range records[1:] {
+		assert.NotZero(t, r[0])
+		assert.Equal(t, "csv_test.go", r[1])
+		assert.NotZero(t, r[2])
+		assert.Contains(t, r[3], "TestWriteCSV")
+		assert.Equal(t, msgs[i], r[5])
+	}
+	assert.Zero(t, records[1][4])
+	assert.Equal(t, "k:v", records[3][4])
+
+	d.Flush()
+	sb.Reset()
+	require.NoError(t, d.WriteCSV(sb))
+	assert.Equal(t, 3, strings.Count(sb.String(), "\n"))
+}
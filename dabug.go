@@ -5,77 +5,262 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"runtime/debug"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // Utility for printing multi or single line statements to aid
 // tracking execution.
 
-// Add easy log for variables
 // Add single line quick logs that do not require flush
-// switch context to be map, sort the keys
 
 type Dabugger struct {
+	*state
+	// disabled drops everything written through this Dabugger, see V
+	disabled bool
+	// tags are recorded with every line, see Tag
+	tags []string
+	// with are context pairs added after the shared contexts, see With
+	with Fields
+	// limit decides which lines are written per call site, see Once
+	limit *limiter
+}
+
+// state is shared between a Dabugger and the Dabuggers derived from it.
+type state struct {
 	// lines contains lines waiting to be flushed
-	lines      []*line
+	lines      []*Line
 	linesMutex sync.Mutex
-	contexts   []*context
-	writer     io.Writer
-	linePrefix string
-	autoFlush  bool
-	stackSkips int
-}
-
-type context struct {
-	key   string
-	value string
-}
-
-type line struct {
-	msg    string
-	src    *source
-	prefix string
-}
-
-type source struct {
+	// armed holds the most recent lines until Fire when set, see Arm
+	armed *RingWriter
+	// contexts are replaced instead of modified so that lines can be
+	// captured while they change, updates hold contextsMutex
+	contexts      atomic.Pointer[Fields]
+	contextsMutex sync.Mutex
+	writers       []io.Writer
+	linePrefix    string
+	autoFlush     bool
+	formatter     Formatter
+	// color forces colored output on or off, nil detects it from writer
+	color  *bool
+	timing TimingMode
+	// showGoroutine includes the goroutine in line headers
+	showGoroutine bool
+	lastTime      time.Time
+	sectionStart  time.Time
+	sectionBeg    string
+	sectionEnd    string
+	sectionName   string
+	// sections is the stack of open nested sections
+	sections []openSection
+	// retained contains up to retain lines that have already been written
+	retained []*Line
+	retain   int
+	// alignColumns aligns source, context, and message independently
+	alignColumns bool
+	maxWidth     int
+	summary      bool
+	// stringers prefers the error and fmt.Stringer methods in Objs output
+	stringers   bool
+	maxValueLen int
+	humanize    bool
+	// maxDepth, maxElems, and pretty control how Objs renders values
+	maxDepth int
+	maxElems int
+	pretty   bool
+	// redact contains the lower cased field and map key names rendered as
+	// redacted by Objs
+	redact map[string]bool
+	// redactor scrubs context and Objs values before they are written
+	redactor Redactor
+	// timeLayout and timeLoc render time.Time values in Objs when set
+	timeLayout string
+	timeLoc    *time.Location
+	// pointerIDs numbers the pointers rendered by Objs when enabled,
+	// lastPointerID is the last number assigned
+	pointerIDs    map[visit]int
+	lastPointerID int
+	// errStack includes the caller stack in CheckErr lines
+	errStack bool
+	// assertPanics makes a failed Assert panic after it is written
+	assertPanics bool
+	// onWriteError is called for every failed write
+	onWriteError func(error)
+	dropped      atomic.Uint64
+	syncOnFlush  bool
+	// timers contains the running named timers
+	timers      map[string]*timer
+	timersMutex sync.Mutex
+	// traces is the depth of active Trace calls per goroutine
+	traces map[int64]int
+	// counters, observations, and gauges are reported and reset by Report
+	// and Flush
+	counters     map[string]*counter
+	observations map[string][]time.Duration
+	gauges       map[string][]float64
+	// memStats is the previous snapshot taken by MemStats or MemDelta
+	memStats *runtime.MemStats
+	// goroutines are the ids recorded by SnapshotGoroutines
+	goroutines map[int64]bool
+	// ticks are the rates counted by Tick, reported every tickInterval or
+	// tickCalls calls
+	ticks        map[string]*ticker
+	tickInterval time.Duration
+	tickCalls    int
+	// watches are written at every Flush
+	watches []watch
+	// sites are the call sites of limited Dabuggers, the suppressed lines
+	// are noted at every Flush if noteSuppressed is set
+	sites          map[Source]*site
+	noteSuppressed bool
+	statsMutex     sync.Mutex
+	// verbosity is the highest level enabled by V, quiet is the disabled
+	// Dabugger V returns above it
+	verbosity int
+	quiet     *Dabugger
+	// off disables all output, see Enabled
+	off atomic.Bool
+	// window limits output to a time window when set
+	window atomic.Pointer[window]
+	// enabledTags limits output to lines with one of the tags when set
+	enabledTags atomic.Pointer[map[string]bool]
+	// fileFilter limits output to lines from matching files when set
+	fileFilter atomic.Pointer[[]string]
+	// funcFilter limits output to lines from matching functions when set
+	funcFilter atomic.Pointer[funcFilter]
+	// grep limits written lines to those matching it when set
+	grep atomic.Pointer[regexp.Regexp]
+}
+
+// Field is a single key/value context pair.
+type Field struct {
+	Key   string
+	Value string
+}
+
+// Fields is an ordered list of context pairs.
+type Fields []Field
+
+func (f Fields) String() string {
+	kvs := make([]string, len(f))
+	for i, c := range f {
+		kvs[i] = fmt.Sprintf("%s:%s", c.Key, c.Value)
+	}
+	return strings.Join(kvs, ", ")
+}
+
+// Line is a single statement captured by a Dabugger.
+type Line struct {
+	Source
+	// Prefix is the line prefix of the Dabugger at the time of capture.
+	Prefix  string
+	Msg     string
+	Context Fields
+	Level   Level
+	Time    time.Time
+	// Delta is the time since the previous line.
+	Delta time.Duration
+	// Elapsed is the time since the first line of the current section.
+	Elapsed time.Duration
+	// Depth is the number of nested sections open when the line was captured.
+	Depth int
+	// Goroutine is the id of the goroutine that captured the line.
+	Goroutine int64
+	// GoroutineLabel is the label set with LabelGoroutine, if any.
+	GoroutineLabel string
+	// Tags are the tags of the Dabugger that captured the line.
+	Tags []string
+
+	// repeats is the number of identical lines collapsed into this one
+	repeats int
+	// timing is the timing mode at the time of capture
+	timing TimingMode
+	// showGoroutine is set if the goroutine is included in the header
+	showGoroutine bool
+	// header is the rendered text prefix used by the default format.
+	header string
+	// lazy renders Msg when the line is appended, see MsgFunc
+	lazy func() string
+}
+
+// Source is the location a line was captured from.
+type Source struct {
+	// File is only the base name of the file, ie: `handler.go`, so files of
+	// different packages with the same name are not told apart, see Path.
 	File     string
 	Function string
 	Line     int
+
+	// path is the full path of File, see FilterFiles
+	path string
 }
 
-func (s source) String() string {
+func (s Source) String() string {
 	return fmt.Sprintf("%s:%d", s.File, s.Line)
 }
 
+// Path returns the full path of File as recorded by the compiler, it is
+// empty for a Source that was not captured by a Dabugger.
+func (s Source) Path() string {
+	return s.path
+}
+
 var (
-	defDabugger = &Dabugger{}
+	defDabugger *Dabugger
 	sectionBeg  = "-----"
 	sectionEnd  = "====="
+	indent      = "  "
+	pkgDir      = func() string {
+		_, file, _, _ := runtime.Caller(0)
+		return filepath.Dir(file)
+	}()
 	// baseDir   string
 )
 
 func init() {
 	defDabugger = New()
 	defDabugger.linePrefix = "DABUG: "
-	defDabugger.stackSkips++
+	defDabugger.off.Store(envDisabled(os.Getenv("DABUG")))
+	if tags := os.Getenv("DABUG_TAGS"); tags != "" {
+		defDabugger.EnableTags(strings.Split(tags, ",")...)
+	}
 }
 
-func New() *Dabugger {
+// New creates a Dabugger that writes to stdout, or as configured by opts.
+func New(opts ...Option) *Dabugger {
 	prefix := ""
 	if defDabugger != nil && defDabugger.linePrefix != "" {
 		// Inherit the line prefix from the default debugger
 		prefix = defDabugger.linePrefix
 	}
 
-	return &Dabugger{
-		writer:     os.Stdout,
+	d := &Dabugger{state: &state{
+		writers:    []io.Writer{os.Stdout},
 		autoFlush:  true,
-		stackSkips: 4,
 		linePrefix: prefix,
-	}
+		sectionBeg: sectionBeg,
+		sectionEnd: sectionEnd,
+		maxDepth:   defMaxDepth,
+		maxElems:   defMaxElems,
+
+		noteSuppressed: true,
+	}}
+	d.Configure(opts...)
+
+	return d
+}
+
+// derive returns a copy of d that shares its state, used to create the
+// Dabuggers returned by With, Tag, and the call site limits, ie: Once.
+func (d *Dabugger) derive() *Dabugger {
+	child := *d
+	return &child
 }
 
 // Stack dumps the last num lines of the stack trace, set num to any
@@ -85,6 +270,10 @@ func Stack(num int) {
 }
 
 func (d *Dabugger) Stack(num int) {
+	if !d.enabled() {
+		return
+	}
+
 	stackLines := strings.Split(string(debug.Stack()), "\n")
 	for i, line := range stackLines {
 		d.appendMsg(line)
@@ -94,13 +283,31 @@ func (d *Dabugger) Stack(num int) {
 	}
 }
 
-// Writer sets the writer to print statements to.
+// Writer sets the writer to print statements to, replacing any writers
+// added with AddWriter.
 func Writer(writer io.Writer) {
 	defDabugger.Writer(writer)
 }
 
 func (d *Dabugger) Writer(writer io.Writer) {
-	d.writer = writer
+	d.writers = []io.Writer{writer}
+}
+
+// LineWriter is implemented by writers that consume structured lines, when
+// used as a writer WriteLine is called for each line instead of Write and
+// section delimiters are not written.
+type LineWriter interface {
+	io.Writer
+	WriteLine(l Line) error
+}
+
+// AddWriter adds another writer that all statements are also printed to.
+func AddWriter(writer io.Writer) {
+	defDabugger.AddWriter(writer)
+}
+
+func (d *Dabugger) AddWriter(writer io.Writer) {
+	d.writers = append(d.writers, writer)
 }
 
 // LinePrefix sets a prefix to prepend to every line printed.
@@ -112,6 +319,28 @@ func (d *Dabugger) LinePrefix(prefix string) {
 	d.linePrefix = prefix
 }
 
+// Delimiters sets the lines written before and after each flushed section.
+func Delimiters(beg, end string) {
+	defDabugger.Delimiters(beg, end)
+}
+
+func (d *Dabugger) Delimiters(beg, end string) {
+	d.sectionBeg = beg
+	d.sectionEnd = end
+}
+
+// Section names the section that will be written by the next Flush.
+func Section(name string) {
+	defDabugger.Section(name)
+}
+
+func (d *Dabugger) Section(name string) {
+	d.linesMutex.Lock()
+	defer d.linesMutex.Unlock()
+
+	d.sectionName = name
+}
+
 func AutoFlush(flush bool) {
 	defDabugger.AutoFlush(flush)
 }
@@ -128,7 +357,10 @@ func Msg(format string, v ...any) {
 }
 
 func (d *Dabugger) Msg(format string, v ...any) {
-	d.appendMsg(fmt.Sprintf(format, v...))
+	if !d.enabled() {
+		return
+	}
+	d.appendMsg(d.truncate(fmt.Sprintf(format, d.humanArgs(v)...)))
 }
 
 func Here() {
@@ -139,18 +371,27 @@ func (d *Dabugger) Here() {
 	d.appendEmpty()
 }
 
-// Objs will append a line to the logger with things printed.
+// Objs will append a line to the logger with things printed, map keys
+// are printed in sorted order so output can be diffed between runs and
+// pointers are followed to print their pointee.
 func Objs(things ...any) {
 	defDabugger.Objs(things...)
 }
 
 func (d *Dabugger) Objs(things ...any) {
+	if !d.enabled() {
+		return
+	}
+	d.appendMsg(d.objsStr(things))
+}
+
+func (d *Dabugger) objsStr(things []any) string {
 	var msgs []string
 	for i, t := range things {
-		msg := fmt.Sprintf("[%d] %#v", i, t)
+		msg := fmt.Sprintf("[%d] %s", i, d.objStr(d.redactValue("", t)))
 		msgs = append(msgs, msg)
 	}
-	d.appendMsg(strings.Join(msgs, ", "))
+	return strings.Join(msgs, ", ")
 }
 
 // AddContext adds a key/value pair that will be prepended to log
@@ -159,7 +400,9 @@ func AddContext(key, value string) {
 }
 
 func (d *Dabugger) AddContext(key, value string) {
-	d.contexts = append(d.contexts, &context{key, value})
+	d.updateContexts(func(ctx Fields) Fields {
+		return append(slices.Clip(ctx), Field{key, value})
+	})
 }
 
 func RemoveContext(key string) {
@@ -167,14 +410,15 @@ func RemoveContext(key string) {
 }
 
 func (d *Dabugger) RemoveContext(key string) {
-	newContexts := []*context{}
-	for _, c := range d.contexts {
-		if c.key != key {
-			newContexts = append(newContexts, c)
+	d.updateContexts(func(ctx Fields) Fields {
+		newContexts := Fields{}
+		for _, c := range ctx {
+			if c.Key != key {
+				newContexts = append(newContexts, c)
+			}
 		}
-	}
-
-	d.contexts = newContexts
+		return newContexts
+	})
 }
 
 func RemoveAllContext() {
@@ -182,8 +426,7 @@ func RemoveAllContext() {
 }
 
 func (d *Dabugger) RemoveAllContext() {
-	clear(d.contexts)
-	d.contexts = nil
+	d.updateContexts(func(Fields) Fields { return nil })
 }
 
 func RemoveTopContext() {
@@ -191,66 +434,287 @@ func RemoveTopContext() {
 }
 
 func (d *Dabugger) RemoveTopContext() {
-	d.contexts = d.contexts[:len(d.contexts)-1]
+	d.updateContexts(func(ctx Fields) Fields {
+		if len(ctx) == 0 {
+			return ctx
+		}
+		return ctx[:len(ctx)-1]
+	})
+}
+
+// Context adds a key/value pair as with AddContext and returns a func that
+// removes it again, ie:
+//
+//	defer dabug.Context("order", id)()
+func Context(key, value string) func() {
+	return defDabugger.Context(key, value)
+}
+
+func (d *Dabugger) Context(key, value string) func() {
+	d.AddContext(key, value)
+
+	return func() {
+		d.updateContexts(func(ctx Fields) Fields {
+			for i := len(ctx) - 1; i >= 0; i-- {
+				if ctx[i] == (Field{key, value}) {
+					return slices.Delete(slices.Clone(ctx), i, i+1)
+				}
+			}
+			return ctx
+		})
+	}
+}
+
+// lineContexts returns a copy of the contexts for a line written by the
+// goroutine id: the shared contexts, then the contexts inherited by the
+// goroutine and the contexts added by With that are not already included.
+// Goroutines started with Go inherit the With contexts of the Dabugger
+// starting them, so the same pair can be both inherited and added by With.
+func (d *Dabugger) lineContexts(id int64) Fields {
+	ctx := slices.Clone(d.currentContexts())
+	for _, fields := range []Fields{inheritedContexts(id), d.with} {
+		for _, f := range fields {
+			if !slices.Contains(ctx, f) {
+				ctx = append(ctx, f)
+			}
+		}
+	}
+	return ctx
 }
 
+// updateContexts replaces the contexts with the result of fn, fn must not
+// modify the current contexts.
+func (d *Dabugger) updateContexts(fn func(ctx Fields) Fields) {
+	d.contextsMutex.Lock()
+	defer d.contextsMutex.Unlock()
+
+	ctx := fn(d.currentContexts())
+	d.contexts.Store(&ctx)
+}
+
+// currentContexts returns the contexts, the result must not be modified.
+func (d *Dabugger) currentContexts() Fields {
+	if ctx := d.contexts.Load(); ctx != nil {
+		return *ctx
+	}
+	return nil
+}
+
+// Flush writes the pending lines as a section, lines captured by concurrent
+// goroutines are written as a section per goroutine named after it.
 func Flush() {
 	defDabugger.Flush()
 }
 
 func (d *Dabugger) Flush() {
+	d.writeWatches()
+	d.writeSuppressed()
+	d.Report()
+
 	d.linesMutex.Lock()
 	defer d.linesMutex.Unlock()
 
+	// the next line starts a new section
+	d.sectionStart = time.Time{}
+
+	d.lines = d.grepLines(d.lines)
 	if len(d.lines) == 0 {
 		// Nothing to do
 		return
 	}
 
+	// lines of concurrent goroutines are written as a section each
+	groups := groupByGoroutine(d.lines)
+	name := d.sectionName
+	var written []*Line
+	for _, group := range groups {
+		d.lines = collapseLines(group)
+		if len(groups) > 1 {
+			d.sectionName = strings.TrimSpace(name + " " + goroutineName(group[0]))
+		}
+		d.write(d.lines, d.sectionStr)
+		written = append(written, d.lines...)
+	}
+	d.lines = written
+	d.clearLines()
+}
+
+// sectionStr renders the pending lines, must be called with linesMutex held.
+func (d *Dabugger) sectionStr(opts textOpts) string {
+	if d.formatter != nil {
+		sb := strings.Builder{}
+		for _, l := range d.lines {
+			sb.WriteString(d.formatter.Format(l) + "\n")
+		}
+		return sb.String()
+	}
+
+	if d.alignColumns {
+		return d.columnsStr(opts)
+	}
+
 	// preprocess line prefix len so that all messages are aligned
 	maxPrefixLen := -1
 	for _, l := range d.lines {
-		maxPrefixLen = max(maxPrefixLen, len(l.prefix))
+		maxPrefixLen = max(maxPrefixLen, len(l.header))
 	}
 
 	sb := strings.Builder{}
-	sb.WriteString(fmt.Sprintf("%s%s\n", d.linePrefix, sectionBeg))
+	sb.WriteString(fmt.Sprintf("%s%s\n", d.linePrefix, delimStr(d.sectionBeg, d.sectionName)))
 
-	lFmt := fmt.Sprintf("%%-%ds", maxPrefixLen)
 	for _, l := range d.lines {
-		sb.WriteString(lineStr(lFmt, l) + "\n")
+		sb.WriteString(lineStr(maxPrefixLen, l, opts) + "\n")
 	}
 
-	sb.WriteString(fmt.Sprintf("%s%s\n", d.linePrefix, sectionEnd))
+	sb.WriteString(d.sectionFooter())
 
-	fmt.Fprint(d.writer, sb.String())
+	return sb.String()
+}
 
-	d.clearLines()
+// sectionFooter renders the end delimiter of the pending lines followed by
+// the optional summary, must be called with linesMutex held.
+func (d *Dabugger) sectionFooter() string {
+	footer := fmt.Sprintf("%s%s\n", d.linePrefix, delimStr(d.sectionEnd, d.sectionName))
+	if d.summary {
+		footer += fmt.Sprintf("%s%s\n", d.linePrefix, summaryStr(d.lines))
+	}
+	return footer
 }
 
-func (d *Dabugger) flushLine(l *line) {
-	msg := lineStr("%s", l)
-	fmt.Fprintf(d.writer, "%s\n", msg)
+func delimStr(delim, name string) string {
+	if name == "" {
+		return delim
+	}
+	return fmt.Sprintf("%s %s %s", delim, name, delim)
+}
+
+func (d *Dabugger) flushLine(l *Line) {
+	d.write([]*Line{l}, func(opts textOpts) string {
+		if d.formatter != nil {
+			return d.formatter.Format(l) + "\n"
+		}
+		return lineStr(0, l, opts) + "\n"
+	})
+}
+
+// write renders the output for each writer and writes it, output is only
+// rendered once per distinct set of text options. LineWriters are given
+// lines instead. A failing writer does not prevent writing to the others.
+func (d *Dabugger) write(lines []*Line, render func(opts textOpts) string) {
+	rendered := map[textOpts]string{}
+	for _, w := range d.writers {
+		if lw, ok := w.(LineWriter); ok {
+			for _, l := range lines {
+				if err := lw.WriteLine(*l); err != nil {
+					d.writeFailed(err, 1)
+				}
+			}
+			continue
+		}
+
+		opts := d.textOpts(w)
+		out, ok := rendered[opts]
+		if !ok {
+			out = render(opts)
+			rendered[opts] = out
+		}
+
+		if _, err := io.WriteString(w, out); err != nil {
+			d.writeFailed(err, len(lines))
+			continue
+		}
+
+		d.sync(w)
+	}
 }
 
-func lineStr(lFmt string, l *line) string {
-	var msg string
+// lineStr renders l in the default text format with the header padded
+// to width.
+func lineStr(width int, l *Line, opts textOpts) string {
+	header := l.header
+	if opts.color {
+		header = headerStr(l, true)
+	}
+	width = max(width, len(l.header))
+	msg := header + strings.Repeat(" ", width-len(l.header))
+	if len(l.Msg) == 0 {
+		return msg
+	}
 
-	if len(l.msg) == 0 {
-		msg = fmt.Sprintf(lFmt, l.prefix)
-	} else {
-		suffix := "- %s"
-		msg = fmt.Sprintf(lFmt+suffix, l.prefix, l.msg)
+	// continuation lines keep the line prefix and are aligned with the
+	// first line of the message
+	cont := l.Prefix + strings.Repeat(" ", width-len(l.Prefix))
+	for i, m := range opts.wrap(l, width+len("- ")) {
+		body := m
+		if opts.color {
+			body = colorize(colorMsg, body)
+		}
+
+		if i == 0 {
+			msg += "- " + levelStr(l, opts.color) + body
+		} else {
+			msg += "\n" + cont + "| " + body
+		}
 	}
 
 	return msg
 }
 
-func (d *Dabugger) appendLine(line *line) {
+func headerStr(l *Line, color bool) string {
+	src := sourceStr(l)
+	c := ""
+	if len(l.Context) > 0 {
+		c = fmt.Sprintf("(%s)", l.Context)
+	}
+
+	if color {
+		src = colorize(colorSource, src)
+		if c != "" {
+			c = colorize(colorContext, c)
+		}
+	}
+	if c != "" {
+		c = " " + c
+	}
+
+	return fmt.Sprintf("%s%s%s ", l.Prefix, src, c)
+}
+
+func (d *Dabugger) appendLine(line *Line) {
+	if !d.enabled() || !d.sourceEnabled(line.Source) {
+		return
+	}
+	ok, note := d.allowSite(line.Source)
+	if !ok {
+		return
+	}
+
+	if line.lazy != nil {
+		line.Msg = line.lazy()
+		line.lazy = nil
+	}
+	line.Msg += note
+
+	d.stamp(line)
 	d.genPrefix(line)
 
+	d.linesMutex.Lock()
+	armed := d.armed
+	d.linesMutex.Unlock()
+	if armed != nil {
+		armed.WriteLine(*line)
+		return
+	}
+
 	if d.autoFlush {
+		if !d.grepMatch(line) {
+			return
+		}
 		d.flushLine(line)
+
+		d.linesMutex.Lock()
+		d.retainLines(line)
+		d.linesMutex.Unlock()
 		return
 	}
 
@@ -261,69 +725,128 @@ func (d *Dabugger) appendLine(line *line) {
 }
 
 func (d *Dabugger) appendEmpty() {
-	line := &line{src: d.getSource()}
+	if !d.enabled() {
+		return
+	}
+	line := &Line{Source: d.getSource()}
 	d.appendLine(line)
 }
 
 func (d *Dabugger) appendMsg(msg string) {
-	line := &line{
-		msg: msg,
-		src: d.getSource(),
+	if !d.enabled() {
+		return
+	}
+	line := &Line{
+		Source: d.getSource(),
+		Msg:    msg,
 	}
 	d.appendLine(line)
 }
 
-func (d *Dabugger) genPrefix(line *line) {
-	c := strings.Builder{}
-	if len(d.contexts) > 0 {
-		c.WriteString(" (")
+func (d *Dabugger) appendLevel(level Level, msg string) {
+	if !d.enabled() {
+		return
+	}
+	line := &Line{
+		Source: d.getSource(),
+		Msg:    msg,
+		Level:  level,
 	}
-	for i := 0; i < len(d.contexts); i++ {
-		context := d.contexts[i]
-		kv := fmt.Sprintf("%s:%s", context.key, context.value)
+	d.appendLine(line)
+}
 
-		if i > 0 {
-			c.WriteString(", ")
-		}
-		c.WriteString(kv)
+// stamp records the time, goroutine, and nesting depth of line.
+func (d *Dabugger) stamp(line *Line) {
+	now := time.Now()
+	line.Goroutine = goid()
+	line.GoroutineLabel = goroutineLabel(line.Goroutine)
+
+	d.linesMutex.Lock()
+	defer d.linesMutex.Unlock()
+
+	if d.sectionStart.IsZero() {
+		d.sectionStart = now
 	}
-	if len(d.contexts) > 0 {
-		c.WriteString(")")
+	if !d.lastTime.IsZero() {
+		line.Delta = now.Sub(d.lastTime)
 	}
 
-	p := fmt.Sprintf("%s%s%s ", d.linePrefix, line.src, c.String())
-
-	line.prefix = p
+	line.Time = now
+	line.Elapsed = now.Sub(d.sectionStart)
+	line.timing = d.timing
+	line.showGoroutine = d.showGoroutine
+	line.Depth = len(d.sections)
+	d.lastTime = now
 }
 
-func (d *Dabugger) getSource() *source {
-	var pc uintptr
-	var pcs [1]uintptr
-
-	// skip
-	// 1. runtime.Callers
-	// 2. getSource
-	// 3. appendLine
-	// 4. Msg, Objs, etc.
-	runtime.Callers(d.stackSkips, pcs[:])
-	pc = pcs[0]
+func (d *Dabugger) genPrefix(line *Line) {
+	line.Prefix = d.linePrefix
+	line.Tags = d.tags
+	line.Context = d.redactContext(d.lineContexts(line.Goroutine))
+	line.header = headerStr(line, false)
+}
 
-	fs := runtime.CallersFrames([]uintptr{pc})
-	f, _ := fs.Next()
+func (d *Dabugger) getSource() Source {
+	var pcs [32]uintptr
 
-	_, fpath, _, _ := runtime.Caller(3)
+	// skip runtime.Callers and getSource, then walk out of this package
+	// so that helpers, wrappers, and returned closures all resolve to the
+	// code that called into dabug.
+	n := runtime.Callers(2, pcs[:])
+	fs := runtime.CallersFrames(pcs[:n])
 
-	var file string
-	file = strings.TrimPrefix(f.File, filepath.Dir(fpath))
-	file = strings.TrimPrefix(file, "/")
+	var f runtime.Frame
+	for more := true; more; {
+		f, more = fs.Next()
+		if !internalFrame(f) {
+			break
+		}
+	}
 
-	return &source{
-		File:     file,
+	return Source{
+		File:     filepath.Base(f.File),
 		Function: f.Function,
 		Line:     f.Line,
+		path:     f.File,
+	}
+}
+
+// internalFrame reports if f belongs to the dabug package itself, tests
+// of the package count as callers.
+func internalFrame(f runtime.Frame) bool {
+	return filepath.Dir(f.File) == pkgDir && !strings.HasSuffix(f.File, "_test.go")
+}
+
+// Retain keeps the last num written lines in memory so that they can be
+// exported after flushing, 0 disables retention.
+func Retain(num int) {
+	defDabugger.Retain(num)
+}
+
+func (d *Dabugger) Retain(num int) {
+	d.linesMutex.Lock()
+	defer d.linesMutex.Unlock()
+
+	d.retain = max(num, 0)
+	d.retainLines()
+}
+
+// retainLines adds lines to the retained history, dropping the oldest
+// lines past the limit. Must be called with linesMutex held.
+func (d *Dabugger) retainLines(lines ...*Line) {
+	if d.retain == 0 {
+		d.retained = nil
+		return
+	}
+
+	d.retained = append(d.retained, lines...)
+	if over := len(d.retained) - d.retain; over > 0 {
+		d.retained = slices.Delete(d.retained, 0, over)
 	}
 }
 
 func (d *Dabugger) clearLines() {
-	d.lines = []*line{}
+	d.retainLines(d.lines...)
+	d.lines = []*Line{}
+	d.sectionName = ""
 }
@@ -1,8 +1,11 @@
 package dabug
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -47,6 +50,42 @@ func TestMsg(t *testing.T) {
 	assert.Zero(t, parts[1])
 }
 
+func TestSourcePath(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.Msg("here")
+	d.Flush()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Equal(t, "dabug_test.go", lines[0].File)
+	assert.True(t, filepath.IsAbs(lines[0].Path()), lines[0].Path())
+	assert.Equal(t, lines[0].File, filepath.Base(lines[0].Path()))
+	assert.Empty(t, Source{File: "a.go"}.Path())
+}
+
+func TestMsgMultiline(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.LinePrefix("P: ")
+	d.AutoFlush(false)
+	d.AddContext("k", "v")
+	d.Msg("one\ntwo")
+	d.RemoveAllContext()
+	d.Msg("three")
+	d.Flush()
+
+	parts := strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 6)
+	assert.True(t, strings.HasSuffix(parts[1], "- one"))
+	assert.Equal(t, strings.Index(parts[1], "- one"), strings.Index(parts[2], "| two"))
+	assert.True(t, strings.HasPrefix(parts[2], "P: "))
+	assert.True(t, strings.HasSuffix(parts[2], "| two"))
+	assert.Equal(t, strings.Index(parts[1], "- one"), strings.Index(parts[3], "- three"))
+}
+
 func TestObjs(t *testing.T) {
 	type person struct {
 		name string
@@ -91,6 +130,56 @@ func TestFlush(t *testing.T) {
 	assert.Zero(t, parts[3])
 }
 
+func TestSection(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.LinePrefix("")
+	d.AutoFlush(false)
+	d.Section("first")
+	d.Msg("msg")
+	d.Flush()
+
+	parts := strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 4)
+	assert.Equal(t, sectionBeg+" first "+sectionBeg, parts[0])
+	assert.Equal(t, sectionEnd+" first "+sectionEnd, parts[2])
+
+	sb.Reset()
+	d.Delimiters(">>>", "<<<")
+	d.Msg("msg")
+	d.Flush()
+
+	parts = strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 4)
+	assert.Equal(t, ">>>", parts[0])
+	assert.Equal(t, "<<<", parts[2])
+}
+
+type failWriter struct{}
+
+func (failWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("fail")
+}
+
+func TestAddWriter(t *testing.T) {
+	sb1 := &strings.Builder{}
+	sb2 := &strings.Builder{}
+	d := New()
+	d.Writer(sb1)
+	d.AddWriter(failWriter{})
+	d.AddWriter(sb2)
+	d.Msg("msg")
+
+	assert.Contains(t, sb1.String(), "msg")
+	assert.Equal(t, sb1.String(), sb2.String())
+
+	d.Writer(sb2)
+	d.Msg("only2")
+	assert.NotContains(t, sb1.String(), "only2")
+	assert.Contains(t, sb2.String(), "only2")
+}
+
 func TestPrefix(t *testing.T) {
 	sb := &strings.Builder{}
 
@@ -150,3 +239,58 @@ func TestStack(t *testing.T) {
 	fmt.Print(sb.String())
 
 }
+
+func TestContextConcurrent(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.RemoveTopContext()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				d.AddContext("k", fmt.Sprint(i))
+				d.RemoveContext("k")
+			}
+		}(i)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				d.Msg("msg")
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Empty(t, d.currentContexts())
+	assert.Len(t, rec.Lines(), 400)
+	for _, l := range rec.Lines() {
+		assert.LessOrEqual(t, len(l.Context), 4)
+	}
+}
+
+func TestScopedContext(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	func() {
+		defer d.Context("order", "1")()
+		d.AddContext("user", "bob")
+		func() {
+			defer d.Context("item", "2")()
+			d.Msg("inner")
+		}()
+		d.Msg("outer")
+	}()
+	d.Msg("after")
+
+	lines := rec.Lines()
+	require.Len(t, lines, 3)
+	assert.Equal(t, Fields{{"order", "1"}, {"user", "bob"}, {"item", "2"}}, lines[0].Context)
+	assert.Equal(t, Fields{{"order", "1"}, {"user", "bob"}}, lines[1].Context)
+	assert.Equal(t, Fields{{"user", "bob"}}, lines[2].Context)
+}
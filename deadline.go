@@ -0,0 +1,37 @@
+package dabug
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// CheckDeadline writes how much time remains before the deadline of ctx,
+// ie: `deadline: 1.2s remaining`. If ctx is already done the line is written
+// as an error with the cause, ie:
+// `deadline: expired 3ms ago (context deadline exceeded)`.
+func CheckDeadline(ctx context.Context) {
+	defDabugger.CheckDeadline(ctx)
+}
+
+func (d *Dabugger) CheckDeadline(ctx context.Context) {
+	deadline, hasDeadline := ctx.Deadline()
+	remaining := time.Until(deadline)
+
+	if err := ctx.Err(); err != nil {
+		cause := context.Cause(ctx)
+		if hasDeadline && remaining <= 0 {
+			d.appendLevel(LevelErr, fmt.Sprintf("deadline: expired %s ago (%v)", fmtDuration(-remaining), cause))
+		} else {
+			d.appendLevel(LevelErr, fmt.Sprintf("deadline: canceled (%v)", cause))
+		}
+		return
+	}
+
+	if !hasDeadline {
+		d.appendMsg("deadline: none")
+		return
+	}
+
+	d.appendMsg(fmt.Sprintf("deadline: %s remaining", fmtDuration(remaining)))
+}
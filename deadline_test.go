@@ -0,0 +1,44 @@
+package dabug
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDeadline(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	d.CheckDeadline(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	d.CheckDeadline(ctx)
+	cancel()
+	d.CheckDeadline(ctx)
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	d.CheckDeadline(expired)
+
+	caused, cancelCause := context.WithCancelCause(context.Background())
+	cancelCause(errors.New("shutdown"))
+	d.CheckDeadline(caused)
+
+	lines := rec.Lines()
+	require.Len(t, lines, 5)
+	assert.Equal(t, "deadline: none", lines[0].Msg)
+	assert.Regexp(t, `^deadline: (59m59\.\d+s|1h0m0s) remaining$`, lines[1].Msg)
+	assert.Equal(t, LevelNone, lines[1].Level)
+	assert.Equal(t, "deadline: canceled (context canceled)", lines[2].Msg)
+	assert.Equal(t, LevelErr, lines[2].Level)
+	assert.Regexp(t, `^deadline: expired 1(\.\d+)?s ago \(context deadline exceeded\)$`, lines[3].Msg)
+	assert.Equal(t, LevelErr, lines[3].Level)
+	assert.Equal(t, "deadline: canceled (shutdown)", lines[4].Msg)
+	assert.Equal(t, "deadline_test.go", lines[4].File)
+}
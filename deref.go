@@ -0,0 +1,60 @@
+package dabug
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Deref appends a line with the value at the end of the field chain expr,
+// the first element of expr names root and the rest are followed as fields
+// through pointers and interfaces, ie: `a.B.C = 12`. If a link in the chain
+// is nil the line is a warning naming it, ie: `a.B.C -> nil at B`.
+func Deref(expr string, root any) {
+	defDabugger.Deref(expr, root)
+}
+
+func (d *Dabugger) Deref(expr string, root any) {
+	if !d.enabled() {
+		return
+	}
+
+	names := strings.Split(expr, ".")
+	if d.redacted(names[0]) {
+		d.appendMsg(fmt.Sprintf("%s = %s", expr, redacted))
+		return
+	}
+	v := reflect.ValueOf(d.redactValue(names[0], root))
+
+	for i, name := range names[1:] {
+		prev := names[i]
+		for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				break
+			}
+			v = v.Elem()
+		}
+
+		switch {
+		case !v.IsValid() || (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil():
+			d.appendLevel(LevelWarn, fmt.Sprintf("%s -> nil at %s", expr, prev))
+			return
+		case v.Kind() != reflect.Struct:
+			d.appendLevel(LevelWarn, fmt.Sprintf("%s -> %s is not a struct (%s)", expr, prev, v.Type()))
+			return
+		}
+
+		sf, ok := v.Type().FieldByName(name)
+		if !ok {
+			d.appendLevel(LevelWarn, fmt.Sprintf("%s -> %s has no field %s", expr, prev, name))
+			return
+		}
+		if sf.Tag.Get("dabug") == "redact" || d.redacted(name) {
+			d.appendMsg(fmt.Sprintf("%s = %s", expr, redacted))
+			return
+		}
+		v = d.redactField(name, v.FieldByIndex(sf.Index))
+	}
+
+	d.appendMsg(fmt.Sprintf("%s = %s", expr, d.truncate(d.prettyValue(v))))
+}
@@ -0,0 +1,93 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeref(t *testing.T) {
+	type c struct {
+		Val int
+	}
+	type b struct {
+		C *c
+	}
+	type a struct {
+		B   *b
+		Any any
+	}
+
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	d.Deref("a.B.C.Val", &a{B: &b{C: &c{12}}})
+	d.Deref("a.B.C.Val", &a{B: &b{}})
+	d.Deref("a.B.C", &a{})
+	d.Deref("a.Any.C", a{Any: &b{C: &c{1}}})
+	d.Deref("a.B.Nope", a{B: &b{}})
+	d.Deref("a.B.C.Val.X", a{B: &b{C: &c{1}}})
+	d.Deref("a.B", (*a)(nil))
+
+	lines := rec.Lines()
+	require.Len(t, lines, 7)
+	assert.Equal(t, "a.B.C.Val = 12", lines[0].Msg)
+	assert.Equal(t, LevelNone, lines[0].Level)
+	assert.Equal(t, "a.B.C.Val -> nil at C", lines[1].Msg)
+	assert.Equal(t, LevelWarn, lines[1].Level)
+	assert.Equal(t, "a.B.C -> nil at B", lines[2].Msg)
+	assert.Equal(t, "a.Any.C = &dabug.c{Val:1}", lines[3].Msg)
+	assert.Equal(t, "a.B.Nope -> B has no field Nope", lines[4].Msg)
+	assert.Equal(t, "a.B.C.Val.X -> Val is not a struct (int)", lines[5].Msg)
+	assert.Equal(t, "a.B -> nil at a", lines[6].Msg)
+}
+
+func TestPrettyDerefs(t *testing.T) {
+	d := New()
+	n, s := 5, "str"
+	pn := &n
+
+	assert.Equal(t, `&5`, d.prettyStr(&n))
+	assert.Equal(t, `&&5`, d.prettyStr(&pn))
+	assert.Equal(t, `struct { N *int; S *string }{N:&5, S:&"str"}`, d.prettyStr(struct {
+		N *int
+		S *string
+	}{&n, &s}))
+
+	var self any
+	self = &self
+	assert.Regexp(t, `^&<cycle \(\*interface \{\}\)\(0x[0-9a-f]+\)>$`, d.prettyStr(self))
+}
+
+func TestDerefRedact(t *testing.T) {
+	type creds struct {
+		Password string `dabug:"redact"`
+		Token    string
+		User     string
+	}
+	type a struct {
+		Creds *creds
+	}
+	v := a{&creds{"hunter2", "tok-DEREF", "dave"}}
+
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.Redact("token", "secret")
+
+	d.Deref("a.Creds.Password", v)
+	d.Deref("a.Creds.Token", v)
+	d.Deref("a.Creds.User", v)
+	d.Deref("a.Creds", v)
+	d.Deref("secret.Creds", v)
+
+	lines := rec.Lines()
+	require.Len(t, lines, 5)
+	assert.Equal(t, "a.Creds.Password = ***", lines[0].Msg)
+	assert.Equal(t, "a.Creds.Token = ***", lines[1].Msg)
+	assert.Equal(t, `a.Creds.User = "dave"`, lines[2].Msg)
+	assert.Equal(t, `a.Creds = &dabug.creds{Password:***, Token:***, User:"dave"}`, lines[3].Msg)
+	assert.Equal(t, "secret.Creds = ***", lines[4].Msg)
+}
@@ -0,0 +1,175 @@
+package dabug
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// diffEntry is a single difference found by Diff.
+type diffEntry struct {
+	// op is one of + (added), - (removed), or ~ (changed)
+	op   byte
+	path string
+	a, b reflect.Value
+	// redacted hides a and b, see Redact
+	redacted bool
+}
+
+// Diff appends a line listing the paths that were added, removed, or
+// changed going from a to b, ie:
+//
+//	diff: 2 changes
+//	  ~ .Name: "dave" -> "fred"
+//	  + .Tags["x"]: 1
+func Diff(a, b any) {
+	defDabugger.Diff(a, b)
+}
+
+func (d *Dabugger) Diff(a, b any) {
+	if !d.enabled() {
+		return
+	}
+
+	var entries []diffEntry
+	av, bv := reflect.ValueOf(d.redactValue("", a)), reflect.ValueOf(d.redactValue("", b))
+	d.diffValues(&entries, "", av, bv, map[[2]uintptr]bool{})
+
+	if len(entries) == 0 {
+		d.appendMsg("diff: equal")
+		return
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("diff: %d %s", len(entries), plural(len(entries), "change", "changes")))
+	for _, e := range entries {
+		path := e.path
+		if path == "" {
+			path = "."
+		}
+
+		sb.WriteString(fmt.Sprintf("\n%s%c %s: ", indent, e.op, path))
+		switch {
+		case e.redacted && e.op == '~':
+			sb.WriteString(redacted + " -> " + redacted)
+		case e.redacted:
+			sb.WriteString(redacted)
+		case e.op == '+':
+			sb.WriteString(d.truncate(d.prettyValue(e.b)))
+		case e.op == '-':
+			sb.WriteString(d.truncate(d.prettyValue(e.a)))
+		default:
+			sb.WriteString(d.truncate(d.prettyValue(e.a)) + " -> " + d.truncate(d.prettyValue(e.b)))
+		}
+	}
+
+	d.appendMsg(sb.String())
+}
+
+// diffValues appends the differences between a and b at path to entries,
+// visited contains the pointer pairs being compared to stop on cycles.
+func (d *Dabugger) diffValues(entries *[]diffEntry, path string, a, b reflect.Value, visited map[[2]uintptr]bool) {
+	changed := func() {
+		*entries = append(*entries, diffEntry{op: '~', path: path, a: a, b: b})
+	}
+
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			changed()
+		}
+		return
+	}
+	if a.Type() != b.Type() {
+		changed()
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				changed()
+			}
+			return
+		}
+		if a.Kind() == reflect.Pointer {
+			key := [2]uintptr{a.Pointer(), b.Pointer()}
+			if key[0] == key[1] || visited[key] {
+				return
+			}
+			visited[key] = true
+		}
+		d.diffValues(entries, path, a.Elem(), b.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			f := a.Type().Field(i)
+			d.diffNamed(entries, path+"."+f.Name, f.Name, f.Tag.Get("dabug") == "redact", a.Field(i), b.Field(i), visited)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < max(a.Len(), b.Len()); i++ {
+			p := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= a.Len():
+				*entries = append(*entries, diffEntry{op: '+', path: p, b: b.Index(i)})
+			case i >= b.Len():
+				*entries = append(*entries, diffEntry{op: '-', path: p, a: a.Index(i)})
+			default:
+				d.diffValues(entries, p, a.Index(i), b.Index(i), visited)
+			}
+		}
+	case reflect.Map:
+		keys := a.MapKeys()
+		for _, k := range b.MapKeys() {
+			if !a.MapIndex(k).IsValid() {
+				keys = append(keys, k)
+			}
+		}
+		slices.SortFunc(keys, compareValues)
+
+		for _, k := range keys {
+			name := ""
+			if k.Kind() == reflect.String {
+				name = k.String()
+			}
+			d.diffNamed(entries, fmt.Sprintf("%s[%s]", path, d.prettyValue(k)), name, false, a.MapIndex(k), b.MapIndex(k), visited)
+		}
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if a.Pointer() != b.Pointer() {
+			changed()
+		}
+	default:
+		if !a.Equal(b) {
+			changed()
+		}
+	}
+}
+
+// diffNamed appends the differences between the struct fields or map
+// values a and b named name, a is invalid for added map entries and b for
+// removed ones. Redacted values are compared but only listed as `***`, and
+// the Redactor replacements are compared instead of the values.
+func (d *Dabugger) diffNamed(entries *[]diffEntry, path, name string, tagged bool, a, b reflect.Value, visited map[[2]uintptr]bool) {
+	added, removed := !a.IsValid(), !b.IsValid()
+	if name != "" {
+		a, b = d.redactField(name, a), d.redactField(name, b)
+	}
+
+	e := diffEntry{op: '~', path: path, a: a, b: b, redacted: tagged || d.redacted(name)}
+	switch {
+	case added:
+		e.op = '+'
+	case removed:
+		e.op = '-'
+	case e.redacted:
+		var changes []diffEntry
+		d.diffValues(&changes, path, a, b, visited)
+		if len(changes) == 0 {
+			return
+		}
+	default:
+		d.diffValues(entries, path, a, b, visited)
+		return
+	}
+	*entries = append(*entries, e)
+}
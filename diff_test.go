@@ -0,0 +1,78 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	type address struct {
+		City string
+	}
+	type person struct {
+		Name    string
+		age     int
+		Address *address
+		Tags    map[string]int
+		Items   []int
+		Any     any
+	}
+
+	a := person{"dave", 40, &address{"earth"}, map[string]int{"a": 1, "b": 2}, []int{1, 2, 3}, 1}
+	b := person{"fred", 40, &address{"mars"}, map[string]int{"a": 1, "c": 3}, []int{1, 5}, "1"}
+
+	d, sb := newMsgDabugger()
+	d.Diff(a, b)
+	d.Diff(a, a)
+	d.Diff(&a, (*person)(nil))
+
+	assert.Equal(t, `diff: 7 changes
+  ~ .Name: "dave" -> "fred"
+  ~ .Address.City: "earth" -> "mars"
+  - .Tags["b"]: 2
+  + .Tags["c"]: 3
+  ~ .Items[1]: 2 -> 5
+  - .Items[2]: 3
+  ~ .Any: 1 -> "1"
+diff: equal
+`+"diff: 1 change\n  ~ .: "+d.prettyStr(&a)+" -> (*dabug.person)(nil)\n", sb.String())
+}
+
+func TestDiffCycle(t *testing.T) {
+	a := &prettyNode{Name: "a"}
+	a.Next = a
+	b := &prettyNode{Name: "a"}
+	b.Next = b
+
+	d, sb := newMsgDabugger()
+	d.Diff(a, b)
+	assert.Equal(t, "diff: equal\n", sb.String())
+}
+
+func TestDiffRedact(t *testing.T) {
+	type creds struct {
+		Password string `dabug:"redact"`
+		Token    string
+	}
+	type config struct {
+		Creds creds
+		Keys  map[string]string
+	}
+
+	a := config{creds{"hunter2", "tok-A"}, map[string]string{"token": "tok-MAPA", "token2": "x"}}
+	b := config{creds{"hunter3", "tok-A"}, map[string]string{"region": "us"}}
+
+	d, sb := newMsgDabugger()
+	d.Redact("token", "token2")
+	d.Diff(a, b)
+	d.Diff(a, a)
+
+	assert.Equal(t, `diff: 4 changes
+  ~ .Creds.Password: *** -> ***
+  + .Keys["region"]: "us"
+  - .Keys["token"]: ***
+  - .Keys["token2"]: ***
+diff: equal
+`, sb.String())
+}
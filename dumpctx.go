@@ -0,0 +1,132 @@
+package dabug
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// DumpContext appends a line listing the chain of ctx from the innermost
+// context outwards with the stored values, deadlines, and cancellation, ie:
+//
+//	context:
+//	  value "user" (main.ctxKey) = "dave"
+//	  deadline 2024-01-02T03:04:05Z (1.2s remaining)
+//	  context.Background
+//
+// The standard library contexts are inspected with reflection, other
+// implementations are listed by type.
+func DumpContext(ctx context.Context) {
+	defDabugger.DumpContext(ctx)
+}
+
+func (d *Dabugger) DumpContext(ctx context.Context) {
+	if !d.enabled() {
+		return
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString("context:")
+
+	for c := ctx; c != nil; c = parentContext(c) {
+		sb.WriteString("\n" + indent + d.contextNode(c))
+	}
+
+	d.appendMsg(sb.String())
+}
+
+// contextNode describes a single context in a chain.
+func (d *Dabugger) contextNode(c context.Context) string {
+	v := reflect.ValueOf(c)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Sprint(c)
+	}
+
+	key, val := v.FieldByName("key"), v.FieldByName("val")
+	if key.IsValid() && val.IsValid() {
+		keyType := "<nil>"
+		if key.Kind() == reflect.Interface && !key.IsNil() {
+			keyType = key.Elem().Type().String()
+			if name := key.Elem(); name.Kind() == reflect.String {
+				if d.redacted(name.String()) {
+					return fmt.Sprintf("value %s (%s) = %s", d.prettyValue(key), keyType, redacted)
+				}
+				if val.CanAddr() {
+					// val is unexported, read it through its address for the Redactor
+					val = reflect.NewAt(val.Type(), unsafe.Pointer(val.UnsafeAddr())).Elem()
+				}
+				val = d.redactField(name.String(), val)
+			}
+		}
+		return fmt.Sprintf("value %s (%s) = %s", d.prettyValue(key), keyType, d.truncate(d.prettyValue(val)))
+	}
+
+	status := ""
+	if err := c.Err(); err != nil {
+		status = fmt.Sprintf(" (%v)", context.Cause(c))
+	}
+
+	if _, ok := v.Type().FieldByName("deadline"); ok {
+		deadline, _ := c.Deadline()
+		remaining := time.Until(deadline)
+		when := fmtDuration(remaining) + " remaining"
+		if remaining <= 0 {
+			when = "expired " + fmtDuration(-remaining) + " ago"
+		}
+		return fmt.Sprintf("deadline %s (%s)%s", deadline.Format(time.RFC3339Nano), when, status)
+	}
+	if _, ok := v.Type().FieldByName("done"); ok {
+		return "cancel" + status
+	}
+	if parentContext(c) == nil {
+		if s, ok := c.(fmt.Stringer); ok {
+			return s.String()
+		}
+	}
+
+	return reflect.TypeOf(c).String() + status
+}
+
+// parentContext returns the context c wraps, found as the first field of
+// type context.Context in c or structs embedded in it.
+func parentContext(c context.Context) context.Context {
+	v := reflect.ValueOf(c)
+	switch {
+	case v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Struct:
+		return findContext(v.Elem())
+	case v.Kind() == reflect.Struct:
+		// copy the struct so that its fields are addressable
+		addr := reflect.New(v.Type()).Elem()
+		addr.Set(v)
+		return findContext(addr)
+	}
+	return nil
+}
+
+func findContext(v reflect.Value) context.Context {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		switch {
+		case f.Type() == contextType:
+			// the fields are unexported, read them through their address
+			parent := reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+			if parent.IsNil() {
+				return nil
+			}
+			return parent.Interface().(context.Context)
+		case v.Type().Field(i).Anonymous && f.Kind() == reflect.Struct:
+			if parent := findContext(f); parent != nil {
+				return parent
+			}
+		}
+	}
+	return nil
+}
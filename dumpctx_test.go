@@ -0,0 +1,70 @@
+package dabug
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ctxKey string
+
+func TestDumpContext(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	ctx := context.WithValue(context.Background(), ctxKey("user"), "dave")
+	ctx, cancel := context.WithTimeout(ctx, time.Hour)
+	defer cancel()
+	ctx, cancelCause := context.WithCancelCause(ctx)
+	cancelCause(errors.New("shutdown"))
+	ctx = context.WithValue(ctx, "id", 12)
+
+	d.DumpContext(ctx)
+	d.DumpContext(context.TODO())
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	parts := strings.Split(lines[0].Msg, "\n")
+	require.Len(t, parts, 6)
+	assert.Equal(t, "context:", parts[0])
+	assert.Equal(t, `  value "id" (string) = 12`, parts[1])
+	assert.Equal(t, "  cancel (shutdown)", parts[2])
+	assert.Regexp(t, `^  deadline \S+ \((59m59\.\d+s|1h0m0s) remaining\)$`, parts[3])
+	assert.Equal(t, `  value "user" (dabug.ctxKey) = "dave"`, parts[4])
+	assert.Equal(t, "  context.Background", parts[5])
+
+	assert.Equal(t, "context:\n  context.TODO", lines[1].Msg)
+
+	rec.Reset()
+	d.DumpContext(context.WithoutCancel(context.WithValue(context.Background(), ctxKey("k"), 1)))
+	require.Len(t, rec.Lines(), 1)
+	assert.Equal(t, "context:\n  context.withoutCancelCtx\n  value \"k\" (dabug.ctxKey) = 1\n  context.Background", rec.Lines()[0].Msg)
+}
+
+func TestDumpContextRedact(t *testing.T) {
+	type creds struct {
+		Password string `dabug:"redact"`
+	}
+
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.Redact("token")
+
+	ctx := context.WithValue(context.Background(), ctxKey("token"), "tok-CTX")
+	ctx = context.WithValue(ctx, "creds", creds{"hunter2"})
+	d.DumpContext(ctx)
+
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Equal(t, "context:\n"+
+		`  value "creds" (string) = dabug.creds{Password:***}`+"\n"+
+		`  value "token" (dabug.ctxKey) = ***`+"\n"+
+		"  context.Background", lines[0].Msg)
+}
@@ -0,0 +1,58 @@
+package dabug
+
+import "strings"
+
+// Enabled reports whether output is enabled, the default Dabugger is
+// disabled at init when the DABUG environment variable is set to one of
+// 0, off, false, or no.
+func Enabled() bool {
+	return defDabugger.Enabled()
+}
+
+func (d *Dabugger) Enabled() bool {
+	return d.enabled()
+}
+
+// Mute disables all output until Unmute is called, including the capture of
+// call sites, so muted instrumentation is cheap. Muting affects every
+// Dabugger derived from the same Dabugger, ie: with Tag.
+func Mute() {
+	defDabugger.Mute()
+}
+
+func (d *Dabugger) Mute() {
+	d.SetEnabled(false)
+}
+
+// Unmute enables output disabled by Mute or the DABUG environment variable.
+func Unmute() {
+	defDabugger.Unmute()
+}
+
+func (d *Dabugger) Unmute() {
+	d.SetEnabled(true)
+}
+
+// SetEnabled unmutes or mutes output, see Mute.
+func SetEnabled(enabled bool) {
+	defDabugger.SetEnabled(enabled)
+}
+
+func (d *Dabugger) SetEnabled(enabled bool) {
+	d.off.Store(!enabled)
+}
+
+// enabled reports whether lines written through d are kept.
+func (d *Dabugger) enabled() bool {
+	return !d.disabled && !d.off.Load() && d.inWindow() && d.tagsEnabled()
+}
+
+// envDisabled reports whether the DABUG environment variable value v turns
+// output off.
+func envDisabled(v string) bool {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "0", "off", "false", "no":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package dabug
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvDisabled(t *testing.T) {
+	for _, v := range []string{"0", "off", "OFF", " false ", "no"} {
+		assert.True(t, envDisabled(v), v)
+	}
+	for _, v := range []string{"", "1", "on", "true", "yes", "verbose"} {
+		assert.False(t, envDisabled(v), v)
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	assert.True(t, d.Enabled())
+	assert.False(t, d.V(1).Enabled())
+
+	d.off.Store(true)
+	assert.False(t, d.Enabled())
+	d.Msg("dropped")
+	assert.Empty(t, rec.Lines())
+
+	assert.Equal(t, !envDisabled(os.Getenv("DABUG")), Enabled())
+}
+
+func TestMute(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	tagged := d.Tag("warmup")
+
+	d.Mute()
+	assert.False(t, d.Enabled())
+	d.Msg("dropped")
+	tagged.Msg("dropped")
+	d.Count("dropped")
+	d.Trace()()
+
+	d.Unmute()
+	assert.True(t, tagged.Enabled())
+	d.Msg("kept")
+
+	d.SetEnabled(false)
+	d.Msg("dropped")
+	d.SetEnabled(true)
+	d.Msg("kept")
+	d.Flush()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "kept", lines[0].Msg)
+	assert.Equal(t, "kept", lines[1].Msg)
+}
+
+func TestMuteSkipsDumps(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	calls := 0
+	d.SetRedactor(func(string, any) (any, bool) {
+		calls++
+		return nil, false
+	})
+
+	d.Mute()
+	assert.Zero(t, callDumps(d))
+	d.Flush()
+
+	assert.Zero(t, calls, "muted dumps must not render their values")
+	assert.Nil(t, d.memStats)
+	assert.Nil(t, d.goroutines)
+	assert.Nil(t, d.ticks)
+	assert.Empty(t, rec.Lines())
+}
+
+// callDumps calls the value and runtime dumps of d, the returned size is
+// the result of SizeOf.
+func callDumps(d *Dabugger) Bytes {
+	type pair struct{ A, B int }
+	v := &pair{1, 2}
+
+	d.Var("v", v)
+	d.Vars("v", v)
+	d.Diff(v, &pair{1, 3})
+	d.Deref("v.A", v)
+	d.Summarize(map[string]int{"a": 1})
+	d.DumpContext(context.WithValue(context.Background(), "v", v))
+	d.TypeOf(v)
+	d.Hex("b", []byte("abc"))
+	d.Stack(0)
+	d.Tick("t")
+	d.MemStats()
+	d.MemDelta()
+	d.RuntimeStats()
+	d.SnapshotGoroutines()
+	d.DiffGoroutines()
+	d.Watch("v", v)
+	d.writeWatches()
+	return d.SizeOf(v)
+}
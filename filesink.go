@@ -0,0 +1,160 @@
+package dabug
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// FileWriter is a writer for a file that is rotated when it grows past a
+// maximum size. Rotated files are named path.1 (newest) through path.N.
+type FileWriter struct {
+	path    string
+	maxSize int64
+	keep    int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// FileOpt configures a FileWriter.
+type FileOpt func(*FileWriter)
+
+// FileMaxSize rotates the file before a write would grow it past n bytes,
+// by default files are never rotated.
+func FileMaxSize(n int64) FileOpt {
+	return func(f *FileWriter) {
+		f.maxSize = n
+	}
+}
+
+// FileKeep sets how many rotated files are kept, defaults to 3.
+func FileKeep(n int) FileOpt {
+	return func(f *FileWriter) {
+		f.keep = max(n, 0)
+	}
+}
+
+// FileSink opens path for appending, use it with Writer or AddWriter.
+func FileSink(path string, opts ...FileOpt) (*FileWriter, error) {
+	f := &FileWriter{
+		path: path,
+		keep: 3,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *FileWriter) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	// a failed rotation is reported after writing p to the current file
+	var rotateErr error
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if rotateErr = f.rotate(); f.file == nil {
+			return 0, rotateErr
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return n, err
+}
+
+// Sync commits the current file to stable storage.
+func (f *FileWriter) Sync() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return os.ErrClosed
+	}
+	return f.file.Sync()
+}
+
+func (f *FileWriter) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+func (f *FileWriter) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	f.file = file
+	f.size = fi.Size()
+	return nil
+}
+
+// rotate shifts the existing files up by one and opens a new file, if that
+// fails the file at path is reopened to keep writing without rotating. Must
+// be called with mu held.
+func (f *FileWriter) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+	if err == nil {
+		err = f.shift()
+	}
+	if err != nil {
+		return errors.Join(err, f.open())
+	}
+
+	return f.open()
+}
+
+// shift removes or renames the closed file at path and the rotated files.
+func (f *FileWriter) shift() error {
+	if f.keep == 0 {
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	if err := os.Remove(f.rotated(f.keep)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := f.keep - 1; i > 0; i-- {
+		if err := os.Rename(f.rotated(i), f.rotated(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return os.Rename(f.path, f.rotated(1))
+}
+
+func (f *FileWriter) rotated(n int) string {
+	return fmt.Sprintf("%s.%d", f.path, n)
+}
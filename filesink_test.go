@@ -0,0 +1,95 @@
+package dabug
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dabug.log")
+
+	f, err := FileSink(path, FileMaxSize(10), FileKeep(2))
+	require.NoError(t, err)
+
+	for _, s := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddd\n", "eeee\n", "ffff\n", "gggg\n"} {
+		_, err := f.Write([]byte(s))
+		require.NoError(t, err)
+	}
+	require.NoError(t, f.Sync())
+	require.NoError(t, f.Close())
+
+	read := func(p string) string {
+		b, err := os.ReadFile(p)
+		require.NoError(t, err)
+		return string(b)
+	}
+	assert.Equal(t, "gggg\n", read(path))
+	assert.Equal(t, "eeee\nffff\n", read(path+".1"))
+	assert.Equal(t, "cccc\ndddd\n", read(path+".2"))
+	assert.NoFileExists(t, path+".3")
+
+	_, err = f.Write([]byte("closed"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+
+	// reopening appends
+	f, err = FileSink(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	d := New()
+	d.Writer(f)
+	d.Msg("msg")
+	assert.True(t, strings.HasPrefix(read(path), "gggg\n"))
+	assert.Contains(t, read(path), "msg")
+}
+
+func TestFileSinkKeepNone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dabug.log")
+
+	f, err := FileSink(path, FileMaxSize(6), FileKeep(0))
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte("aaaa\n"))
+	require.NoError(t, err)
+	_, err = f.Write([]byte("bbbb\n"))
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "bbbb\n", string(b))
+	assert.NoFileExists(t, path+".1")
+}
+
+func TestFileSinkRotateFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dabug.log")
+
+	// a non empty directory cannot be replaced by the rotated file
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "x"), 0o755))
+
+	f, err := FileSink(path, FileMaxSize(6), FileKeep(1))
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write([]byte("aaaa\n"))
+	require.NoError(t, err)
+	n, err := f.Write([]byte("bbbb\n"))
+	assert.Error(t, err)
+	assert.Equal(t, 5, n)
+
+	require.NoError(t, os.RemoveAll(path+".1"))
+	_, err = f.Write([]byte("cccc\n"))
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "cccc\n", string(b))
+	b, err = os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "aaaa\nbbbb\n", string(b))
+}
@@ -0,0 +1,112 @@
+package dabug
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// FilterFiles limits output to lines written from source files matching
+// one of the globs. A glob is matched against as many trailing elements of
+// the file path as it has, so `*.go` matches any file and
+// `*/scheduler/*.go` matches files in any scheduler directory. Call it
+// without globs to write lines from all files again.
+func FilterFiles(globs ...string) error {
+	return defDabugger.FilterFiles(globs...)
+}
+
+func (d *Dabugger) FilterFiles(globs ...string) error {
+	if len(globs) == 0 {
+		d.fileFilter.Store(nil)
+		return nil
+	}
+
+	for _, g := range globs {
+		if _, err := path.Match(g, ""); err != nil {
+			return err
+		}
+	}
+	d.fileFilter.Store(&globs)
+	return nil
+}
+
+// funcFilter holds the compiled FilterFuncs expressions.
+type funcFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// FilterFuncs limits output to lines written from functions matching one of
+// the regular expressions, ie: `^main\.` or `\.\(\*Server\)\.`. Expressions
+// prefixed with `!` exclude matching functions instead, when only exclusions
+// are given all other functions are kept. The expressions are matched
+// against the full function name, ie: `github.com/org/proj/pkg.Func`. Call
+// it without expressions to write lines from all functions again.
+func FilterFuncs(regexps ...string) error {
+	return defDabugger.FilterFuncs(regexps...)
+}
+
+func (d *Dabugger) FilterFuncs(regexps ...string) error {
+	if len(regexps) == 0 {
+		d.funcFilter.Store(nil)
+		return nil
+	}
+
+	f := &funcFilter{}
+	for _, expr := range regexps {
+		list := &f.include
+		if rest, ok := strings.CutPrefix(expr, "!"); ok {
+			expr, list = rest, &f.exclude
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return err
+		}
+		*list = append(*list, re)
+	}
+	d.funcFilter.Store(f)
+	return nil
+}
+
+func (f *funcFilter) match(fn string) bool {
+	for _, re := range f.exclude {
+		if re.MatchString(fn) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, re := range f.include {
+		if re.MatchString(fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceEnabled reports whether lines from src pass the source filters.
+func (d *Dabugger) sourceEnabled(src Source) bool {
+	if globs := d.fileFilter.Load(); globs != nil && !matchFile(*globs, src.path) {
+		return false
+	}
+	if f := d.funcFilter.Load(); f != nil && !f.match(src.Function) {
+		return false
+	}
+	return true
+}
+
+// matchFile reports whether the trailing elements of file match any of globs.
+func matchFile(globs []string, file string) bool {
+	elems := strings.Split(file, "/")
+	for _, g := range globs {
+		n := strings.Count(g, "/") + 1
+		if n > len(elems) {
+			continue
+		}
+		if ok, _ := path.Match(g, strings.Join(elems[len(elems)-n:], "/")); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package dabug
+
+import (
+	"path"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchFile(t *testing.T) {
+	file := "/home/dev/proj/scheduler/queue.go"
+	assert.True(t, matchFile([]string{"*.go"}, file))
+	assert.True(t, matchFile([]string{"*/scheduler/*.go"}, file))
+	assert.True(t, matchFile([]string{"proj/*/q*.go"}, file))
+	assert.True(t, matchFile([]string{"/home/*/*/*/*.go"}, file))
+	assert.False(t, matchFile([]string{"*/api/*.go"}, file))
+	assert.False(t, matchFile([]string{"scheduler"}, file))
+	assert.False(t, matchFile([]string{"a/b/c/d/e/f/*.go"}, file))
+	assert.False(t, matchFile(nil, file))
+}
+
+func TestFilterFiles(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	require.NoError(t, d.FilterFiles("*/dabug/other.go"))
+	d.Msg("dropped")
+	d.Ok("dropped")
+
+	require.NoError(t, d.FilterFiles("other.go", "*/"+path.Base(pkgDir)+"/filter_test.go"))
+	d.Msg("kept")
+
+	require.NoError(t, d.FilterFiles())
+	d.Msg("all")
+
+	assert.ErrorIs(t, d.FilterFiles("[a-"), path.ErrBadPattern)
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "kept", lines[0].Msg)
+	assert.Equal(t, "all", lines[1].Msg)
+}
+
+func TestFuncFilter(t *testing.T) {
+	f := &funcFilter{}
+	assert.True(t, f.match("main.run"))
+
+	f.include = []*regexp.Regexp{regexp.MustCompile(`^main\.`)}
+	assert.True(t, f.match("main.run"))
+	assert.False(t, f.match("net/http.serve"))
+
+	f.exclude = []*regexp.Regexp{regexp.MustCompile(`\.func\d+$`)}
+	assert.True(t, f.match("main.run"))
+	assert.False(t, f.match("main.run.func1"))
+
+	f.include = nil
+	assert.True(t, f.match("net/http.serve"))
+	assert.False(t, f.match("main.run.func1"))
+}
+
+func TestFilterFuncs(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	require.NoError(t, d.FilterFuncs(`\.TestFilterFuncs$`))
+	d.Msg("kept")
+	func() { d.Msg("dropped") }()
+
+	require.NoError(t, d.FilterFuncs(`!\.func\d+$`))
+	d.Msg("kept")
+	func() { d.Msg("dropped") }()
+
+	require.NoError(t, d.FilterFuncs())
+	func() { d.Msg("all") }()
+
+	assert.Error(t, d.FilterFuncs("(oops"))
+
+	lines := rec.Lines()
+	require.Len(t, lines, 3)
+	assert.Equal(t, "kept", lines[0].Msg)
+	assert.Equal(t, "kept", lines[1].Msg)
+	assert.Equal(t, "all", lines[2].Msg)
+}
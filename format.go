@@ -0,0 +1,167 @@
+package dabug
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Formatter renders a Line, the returned string should not include a
+// trailing newline.
+type Formatter interface {
+	Format(l *Line) string
+}
+
+// OutputFormat selects one of the built-in formatters.
+type OutputFormat int
+
+const (
+	// FormatText is the default aligned plain-text layout.
+	FormatText OutputFormat = iota
+	// FormatJSON renders each line as a JSON object, one per line.
+	FormatJSON
+	// FormatLogfmt renders each line as logfmt key=value pairs.
+	FormatLogfmt
+)
+
+// Format sets the output format to one of the built-in formatters.
+func Format(format OutputFormat) {
+	defDabugger.Format(format)
+}
+
+func (d *Dabugger) Format(format OutputFormat) {
+	switch format {
+	case FormatJSON:
+		d.formatter = jsonFormatter{}
+	case FormatLogfmt:
+		d.formatter = logfmtFormatter{}
+	default:
+		d.formatter = nil
+	}
+}
+
+// SetFormatter sets a custom formatter used to render every line, section
+// delimiters are only written by the default text format. A nil formatter
+// restores the default text format.
+func SetFormatter(f Formatter) {
+	defDabugger.SetFormatter(f)
+}
+
+func (d *Dabugger) SetFormatter(f Formatter) {
+	d.formatter = f
+}
+
+// MarshalJSON renders the fields as a JSON object.
+func (f Fields) MarshalJSON() ([]byte, error) {
+	m := make(map[string]string, len(f))
+	for _, c := range f {
+		m[c.Key] = c.Value
+	}
+	return json.Marshal(m)
+}
+
+type jsonLine struct {
+	Prefix  string   `json:"prefix"`
+	File    string   `json:"file"`
+	Line    int      `json:"line"`
+	Func    string   `json:"func"`
+	Msg     string   `json:"msg"`
+	Context Fields   `json:"context"`
+	Depth   int      `json:"depth,omitempty"`
+	Level   string   `json:"level,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+type jsonFormatter struct{}
+
+func newJSONLine(l *Line) jsonLine {
+	return jsonLine{
+		Prefix:  l.Prefix,
+		File:    l.File,
+		Line:    l.Line,
+		Func:    l.Function,
+		Msg:     l.Msg,
+		Context: l.Context,
+		Depth:   l.Depth,
+		Level:   l.Level.String(),
+		Tags:    l.Tags,
+	}
+}
+
+func (jsonFormatter) Format(l *Line) string {
+	b, err := json.Marshal(newJSONLine(l))
+	if err != nil {
+		return fmt.Sprintf(`{"msg":%q}`, err.Error())
+	}
+
+	return string(b)
+}
+
+type logfmtFormatter struct{}
+
+func (logfmtFormatter) Format(l *Line) string {
+	sb := strings.Builder{}
+	writePair := func(key, value string) {
+		if sb.Len() > 0 {
+			sb.WriteString(" ")
+		}
+		sb.WriteString(key)
+		sb.WriteString("=")
+		sb.WriteString(logfmtValue(value))
+	}
+
+	writePair("file", l.File)
+	writePair("line", strconv.Itoa(l.Line))
+	writePair("func", l.Function)
+	writePair("msg", l.Msg)
+	if l.Level != LevelNone {
+		writePair("level", l.Level.String())
+	}
+	for _, c := range l.Context {
+		writePair(c.Key, c.Value)
+	}
+
+	return sb.String()
+}
+
+// logfmtValue quotes value if it would otherwise break logfmt parsing.
+func logfmtValue(value string) string {
+	if value == "" {
+		return `""`
+	}
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f {
+			return strconv.Quote(value)
+		}
+	}
+
+	return value
+}
+
+type templateFormatter struct {
+	tmpl *template.Template
+}
+
+// NewTemplateFormatter creates a Formatter from a text/template string
+// that is executed against each Line, ie:
+//
+//	"{{.File}}:{{.Line}} [{{.Context}}] {{.Msg}}"
+func NewTemplateFormatter(text string) (Formatter, error) {
+	tmpl, err := template.New("dabug").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &templateFormatter{tmpl: tmpl}, nil
+}
+
+func (f *templateFormatter) Format(l *Line) string {
+	sb := strings.Builder{}
+	if err := f.tmpl.Execute(&sb, l); err != nil {
+		return fmt.Sprintf("%s%s - template error: %v", l.Prefix, l.Source, err)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,108 @@
+package dabug
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatJSON(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.Format(FormatJSON)
+	d.AddContext("hello", "world")
+	d.Msg("msg %d", 1)
+
+	d.AutoFlush(false)
+	d.Msg("msg %d", 2)
+	d.Flush()
+
+	parts := strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 3)
+	assert.Zero(t, parts[2])
+
+	for i, p := range parts[:2] {
+		var jl struct {
+			File    string
+			Line    int
+			Func    string
+			Msg     string
+			Context map[string]string
+		}
+		require.NoError(t, json.Unmarshal([]byte(p), &jl))
+		assert.Equal(t, "format_test.go", jl.File)
+		assert.NotZero(t, jl.Line)
+		assert.Contains(t, jl.Func, "TestFormatJSON")
+		assert.Equal(t, map[string]string{"hello": "world"}, jl.Context)
+		assert.Equal(t, []string{"msg 1", "msg 2"}[i], jl.Msg)
+	}
+}
+
+func TestFormatLogfmt(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.Format(FormatLogfmt)
+	d.AddContext("hello", "world")
+	d.AddContext("empty", "")
+	d.Msg("a msg")
+
+	parts := strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 2)
+	assert.Regexp(t, `^file=format_test.go line=\d+ func=\S+TestFormatLogfmt msg="a msg" hello=world empty=""$`, parts[0])
+	assert.Zero(t, parts[1])
+}
+
+type upperFormatter struct{}
+
+func (upperFormatter) Format(l *Line) string {
+	return strings.ToUpper(fmt.Sprintf("%s|%s|%s", l.File, l.Context, l.Msg))
+}
+
+func TestSetFormatter(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.SetFormatter(upperFormatter{})
+	d.AddContext("k", "v")
+	d.Msg("msg")
+
+	d.AutoFlush(false)
+	d.Msg("msg2")
+	d.Flush()
+
+	assert.Equal(t, "FORMAT_TEST.GO|K:V|MSG\nFORMAT_TEST.GO|K:V|MSG2\n", sb.String())
+
+	sb.Reset()
+	d.SetFormatter(nil)
+	d.Msg("msg3")
+	d.Flush()
+	assert.Contains(t, sb.String(), sectionBeg)
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	f, err := NewTemplateFormatter("{{.File}}:{{.Line}} [{{.Context}}] {{.Msg}}")
+	require.NoError(t, err)
+
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.SetFormatter(f)
+	d.AddContext("a", "1")
+	d.AddContext("b", "2")
+	d.Msg("msg")
+
+	assert.Regexp(t, `^format_test.go:\d+ \[a:1, b:2\] msg\n$`, sb.String())
+
+	_, err = NewTemplateFormatter("{{.File")
+	assert.Error(t, err)
+
+	f, err = NewTemplateFormatter("{{.Missing}}")
+	require.NoError(t, err)
+	assert.Contains(t, f.Format(&Line{}), "template error")
+}
@@ -0,0 +1,106 @@
+package dabug
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// sparkWidth is the maximum number of characters in a gauge sparkline.
+const sparkWidth = 40
+
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// sparkGap marks infinite or NaN samples in a sparkline.
+const sparkGap = '·'
+
+// Gauge records a sample v of name, gauges are summarized as first, last,
+// min, max and a sparkline of the trend by Report and Flush.
+func Gauge(name string, v float64) {
+	defDabugger.Gauge(name, v)
+}
+
+func (d *Dabugger) Gauge(name string, v float64) {
+	if !d.enabled() {
+		return
+	}
+
+	d.statsMutex.Lock()
+	defer d.statsMutex.Unlock()
+
+	if d.gauges == nil {
+		d.gauges = map[string][]float64{}
+	}
+	d.gauges[name] = append(d.gauges[name], v)
+}
+
+func gaugesStr(gauges map[string][]float64) string {
+	names := make([]string, 0, len(gauges))
+	for name := range gauges {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	f := func(v float64) string {
+		return strconv.FormatFloat(v, 'g', 6, 64)
+	}
+
+	sb := strings.Builder{}
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "gauge\tfirst\tlast\tmin\tmax\ttrend")
+	for _, name := range names {
+		s := gauges[name]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", name, f(s[0]), f(s[len(s)-1]),
+			f(slices.Min(s)), f(slices.Max(s)), sparkline(s))
+	}
+	tw.Flush()
+
+	return "gauges\n" + strings.TrimSuffix(sb.String(), "\n")
+}
+
+// sparkline renders samples as bars scaled between their min and max,
+// averaging them into at most sparkWidth buckets.
+func sparkline(samples []float64) string {
+	buckets := samples
+	if len(samples) > sparkWidth {
+		buckets = make([]float64, sparkWidth)
+		for i := range buckets {
+			beg, end := i*len(samples)/sparkWidth, (i+1)*len(samples)/sparkWidth
+			sum := 0.0
+			for _, s := range samples[beg:end] {
+				sum += s
+			}
+			buckets[i] = sum / float64(end-beg)
+		}
+	}
+
+	// non-finite samples do not affect the scale and are drawn as sparkGap
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, b := range buckets {
+		if isFinite(b) {
+			lo, hi = min(lo, b), max(hi, b)
+		}
+	}
+
+	sb := strings.Builder{}
+	for _, b := range buckets {
+		if !isFinite(b) {
+			sb.WriteRune(sparkGap)
+			continue
+		}
+		i := 0
+		if hi > lo {
+			i = int((b - lo) / (hi - lo) * float64(len(sparkBars)-1))
+		}
+		sb.WriteRune(sparkBars[min(max(i, 0), len(sparkBars)-1)])
+	}
+
+	return sb.String()
+}
+
+func isFinite(f float64) bool {
+	return !math.IsInf(f, 0) && !math.IsNaN(f)
+}
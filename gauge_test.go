@@ -0,0 +1,74 @@
+package dabug
+
+import (
+	"math"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGauge(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	for _, v := range []float64{3, 1, 7, 14.5, 2} {
+		d.Gauge("queue", v)
+	}
+	d.Gauge("flat", 5)
+	assert.Empty(t, rec.Lines())
+
+	d.Flush()
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Equal(t, "gauges\n"+
+		"gauge  first  last  min  max   trend\n"+
+		"flat   5      5     5    5     ▁\n"+
+		"queue  3      2     1    14.5  ▂▁▄█▁", lines[0].Msg)
+	assert.Nil(t, d.gauges)
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▂▃▄▅▆▇█", sparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}))
+
+	samples := make([]float64, 1000)
+	for i := range samples {
+		samples[i] = float64(i)
+	}
+	s := sparkline(samples)
+	assert.Equal(t, sparkWidth, utf8.RuneCountInString(s))
+	assert.Equal(t, '▁', []rune(s)[0])
+	assert.Equal(t, '█', []rune(s)[sparkWidth-1])
+}
+
+func TestSparklineNonFinite(t *testing.T) {
+	assert.Equal(t, "▁·█·", sparkline([]float64{1, math.Inf(1), 2, math.NaN()}))
+	assert.Equal(t, "·", sparkline([]float64{math.Inf(-1)}))
+
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.Gauge("q", 1)
+	d.Gauge("q", math.Inf(1))
+	d.Gauge("q", math.NaN())
+	assert.NotPanics(t, d.Flush)
+	require.Len(t, rec.Lines(), 1)
+	assert.Contains(t, rec.Lines()[0].Msg, "▁··")
+}
+
+func TestGaugeDisabled(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	d.V(3).Gauge("queue", 1)
+	d.Mute()
+	d.Gauge("queue", 2)
+	d.Unmute()
+
+	assert.Nil(t, d.gauges)
+	d.Flush()
+	assert.Empty(t, rec.Lines())
+}
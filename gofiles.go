@@ -0,0 +1,99 @@
+package dabug
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// GoroutineFileWriter is a LineWriter that writes the lines of each
+// goroutine to its own file named after the goroutine label, or id if it is
+// not labeled.
+type GoroutineFileWriter struct {
+	dir string
+
+	mu    sync.Mutex
+	files map[string]*os.File
+}
+
+// GoroutineFiles creates dir if needed and writes each goroutine's lines to
+// a file within it, use it with Writer or AddWriter.
+func GoroutineFiles(dir string) (*GoroutineFileWriter, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &GoroutineFileWriter{
+		dir:   dir,
+		files: map[string]*os.File{},
+	}, nil
+}
+
+func (g *GoroutineFileWriter) WriteLine(l Line) error {
+	name := fmt.Sprintf("goroutine-%d", l.Goroutine)
+	if l.GoroutineLabel != "" {
+		name = l.GoroutineLabel
+	}
+
+	return g.writeTo(name, lineStr(0, &l, textOpts{})+"\n")
+}
+
+// Write writes text that did not come from a Dabugger to dabug.log.
+func (g *GoroutineFileWriter) Write(p []byte) (int, error) {
+	if err := g.writeTo("dabug", string(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (g *GoroutineFileWriter) writeTo(name, s string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.files == nil {
+		return os.ErrClosed
+	}
+
+	f, ok := g.files[name]
+	if !ok {
+		var err error
+		f, err = os.OpenFile(g.path(name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return err
+		}
+		g.files[name] = f
+	}
+
+	_, err := f.WriteString(s)
+	return err
+}
+
+// path returns the file path for name, replacing characters that are not
+// safe in file names.
+func (g *GoroutineFileWriter) path(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ':' || r < ' ' {
+			return '_'
+		}
+		return r
+	}, name)
+
+	return filepath.Join(g.dir, name+".log")
+}
+
+// Close closes all open files.
+func (g *GoroutineFileWriter) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var errs []error
+	for _, f := range g.files {
+		errs = append(errs, f.Close())
+	}
+	g.files = nil
+
+	return errors.Join(errs...)
+}
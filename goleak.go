@@ -0,0 +1,157 @@
+package dabug
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// goroutineInfo is a goroutine parsed from a full stack dump.
+type goroutineInfo struct {
+	id    int64
+	state string
+	// createdBy is the function and location that started the goroutine
+	createdBy string
+}
+
+// SnapshotGoroutines records the live goroutines for DiffGoroutines.
+func SnapshotGoroutines() {
+	defDabugger.SnapshotGoroutines()
+}
+
+func (d *Dabugger) SnapshotGoroutines() {
+	if !d.enabled() {
+		return
+	}
+
+	ids := map[int64]bool{}
+	for _, g := range liveGoroutines() {
+		ids[g.id] = true
+	}
+
+	d.statsMutex.Lock()
+	defer d.statsMutex.Unlock()
+
+	d.goroutines = ids
+}
+
+// DiffGoroutines writes the goroutines started since SnapshotGoroutines that
+// are still alive, grouped by where they were created with their states, ie:
+//
+//	goroutines: 3 new (12 live)
+//	  3 created by main.startWorkers at main.go:42 [chan receive]
+func DiffGoroutines() {
+	defDabugger.DiffGoroutines()
+}
+
+func (d *Dabugger) DiffGoroutines() {
+	if !d.enabled() {
+		return
+	}
+
+	d.statsMutex.Lock()
+	snapshot := d.goroutines
+	d.statsMutex.Unlock()
+
+	live := liveGoroutines()
+	counts := map[string]int{}
+	states := map[string][]string{}
+	n := 0
+	for _, g := range live {
+		if !snapshot[g.id] {
+			counts[g.createdBy]++
+			if !slices.Contains(states[g.createdBy], g.state) {
+				states[g.createdBy] = append(states[g.createdBy], g.state)
+			}
+			n++
+		}
+	}
+
+	if n == 0 {
+		d.appendMsg(fmt.Sprintf("goroutines: no new (%d live)", len(live)))
+		return
+	}
+
+	sites := make([]string, 0, len(counts))
+	for s := range counts {
+		sites = append(sites, s)
+	}
+	slices.SortFunc(sites, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("goroutines: %d new (%d live)", n, len(live)))
+	for _, s := range sites {
+		slices.Sort(states[s])
+		sb.WriteString(fmt.Sprintf("\n%s%d created by %s [%s]", indent, counts[s], s, strings.Join(states[s], "; ")))
+	}
+	d.appendMsg(sb.String())
+}
+
+// liveGoroutines parses the stacks of all goroutines.
+func liveGoroutines() []goroutineInfo {
+	var gs []goroutineInfo
+	for _, block := range strings.Split(allStacks(), "\n\n") {
+		if g, ok := parseGoroutine(block); ok {
+			gs = append(gs, g)
+		}
+	}
+	return gs
+}
+
+// allStacks returns the stacks of all goroutines.
+func allStacks() string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// parseGoroutine parses a single goroutine of a stack dump, ie:
+//
+//	goroutine 7 [sleep]:
+//	time.Sleep(0x34630b8a000)
+//		/usr/local/go/src/runtime/time.go:368 +0x165
+//	created by main.main in goroutine 1
+//		/tmp/main.go:3 +0x1e
+func parseGoroutine(block string) (goroutineInfo, bool) {
+	lines := strings.Split(strings.TrimSpace(block), "\n")
+	header, ok := strings.CutPrefix(lines[0], "goroutine ")
+	if !ok {
+		return goroutineInfo{}, false
+	}
+
+	id, state, _ := strings.Cut(strings.TrimSuffix(header, ":"), " ")
+	g := goroutineInfo{state: strings.Trim(state, "[]")}
+	var err error
+	if g.id, err = strconv.ParseInt(id, 10, 64); err != nil {
+		return goroutineInfo{}, false
+	}
+
+	g.createdBy = "runtime"
+	for i, l := range lines {
+		fn, ok := strings.CutPrefix(l, "created by ")
+		if !ok {
+			continue
+		}
+		fn, _, _ = strings.Cut(fn, " in goroutine ")
+		g.createdBy = fn
+		if i+1 < len(lines) {
+			loc, _, _ := strings.Cut(strings.TrimSpace(lines[i+1]), " ")
+			g.createdBy += " at " + filepath.Base(loc)
+		}
+	}
+	return g, true
+}
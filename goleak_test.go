@@ -0,0 +1,50 @@
+package dabug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoroutine(t *testing.T) {
+	g, ok := parseGoroutine(`goroutine 7 [chan receive, 2 minutes]:
+main.worker()
+	/src/main.go:10 +0x1d
+created by main.startWorkers in goroutine 1
+	/src/main.go:42 +0x1e`)
+	require.True(t, ok)
+	assert.Equal(t, goroutineInfo{id: 7, state: "chan receive, 2 minutes", createdBy: "main.startWorkers at main.go:42"}, g)
+
+	g, ok = parseGoroutine("goroutine 1 [running]:\nmain.main()\n\t/src/main.go:3 +0x51")
+	require.True(t, ok)
+	assert.Equal(t, goroutineInfo{id: 1, state: "running", createdBy: "runtime"}, g)
+
+	_, ok = parseGoroutine("not a goroutine")
+	assert.False(t, ok)
+}
+
+func TestDiffGoroutines(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	d.SnapshotGoroutines()
+	d.DiffGoroutines()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	for i := 0; i < 3; i++ {
+		go func() { <-stop }()
+	}
+	d.DiffGoroutines()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^goroutines: no new \(\d+ live\)$`, lines[0].Msg)
+
+	msg := strings.Split(lines[1].Msg, "\n")
+	assert.Regexp(t, `^goroutines: 3 new \(\d+ live\)$`, msg[0])
+	assert.Regexp(t, `^  3 created by github.com/dcaravel/dabug.TestDiffGoroutines at goleak_test.go:\d+ \[.+\]$`, msg[1])
+}
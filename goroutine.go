@@ -0,0 +1,66 @@
+package dabug
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// goroutineLabels maps goroutine ids to the labels set by LabelGoroutine.
+var goroutineLabels sync.Map
+
+// goid returns the id of the calling goroutine.
+func goid() int64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	if i := bytes.IndexByte(b, ' '); i >= 0 {
+		b = b[:i]
+	}
+
+	id, _ := strconv.ParseInt(string(b), 10, 64)
+	return id
+}
+
+// LabelGoroutine assigns a label to the calling goroutine that is recorded
+// with every line it emits, the returned func removes the label, ie:
+//
+//	defer dabug.LabelGoroutine("worker-1")()
+func LabelGoroutine(label string) func() {
+	id := goid()
+	goroutineLabels.Store(id, label)
+
+	return func() {
+		goroutineLabels.Delete(id)
+	}
+}
+
+// ShowGoroutine includes the id and label of the goroutine that wrote each
+// line in the line header, ie: `main.go:12 [g7 worker-1]`.
+func ShowGoroutine(enabled bool) {
+	defDabugger.ShowGoroutine(enabled)
+}
+
+func (d *Dabugger) ShowGoroutine(enabled bool) {
+	d.showGoroutine = enabled
+}
+
+func goroutineStr(l *Line) string {
+	if !l.showGoroutine {
+		return ""
+	}
+	if l.GoroutineLabel != "" {
+		return fmt.Sprintf(" [g%d %s]", l.Goroutine, l.GoroutineLabel)
+	}
+	return fmt.Sprintf(" [g%d]", l.Goroutine)
+}
+
+// goroutineLabel returns the label of the goroutine with id.
+func goroutineLabel(id int64) string {
+	if label, ok := goroutineLabels.Load(id); ok {
+		return label.(string)
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package dabug
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoid(t *testing.T) {
+	id := goid()
+	assert.Positive(t, id)
+
+	other := make(chan int64)
+	go func() { other <- goid() }()
+	assert.NotEqual(t, id, <-other)
+}
+
+func TestLabelGoroutine(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	unlabel := LabelGoroutine("main")
+	d.Msg("labeled")
+	unlabel()
+	d.Msg("unlabeled")
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, goid(), lines[0].Goroutine)
+	assert.Equal(t, "main", lines[0].GoroutineLabel)
+	assert.Zero(t, lines[1].GoroutineLabel)
+}
+
+func TestShowGoroutine(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.LinePrefix("")
+	d.Msg("hidden")
+	d.ShowGoroutine(true)
+	d.Msg("plain")
+	unlabel := LabelGoroutine("main")
+	d.Msg("labeled")
+	unlabel()
+
+	parts := strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 4)
+	assert.NotContains(t, parts[0], "[g")
+	assert.Regexp(t, fmt.Sprintf(`^goroutine_test\.go:\d+ \[g%d\] - plain$`, goid()), parts[1])
+	assert.Regexp(t, fmt.Sprintf(`^goroutine_test\.go:\d+ \[g%d main\] - labeled$`, goid()), parts[2])
+}
+
+func TestGoroutineFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	g, err := GoroutineFiles(dir)
+	require.NoError(t, err)
+
+	d := New()
+	d.Writer(g)
+
+	var ids [2]int64
+	wg := sync.WaitGroup{}
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i] = goid()
+			d.Msg("worker %d", i)
+		}(i)
+	}
+	wg.Wait()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer LabelGoroutine("named/worker")()
+		d.Msg("named")
+	}()
+	wg.Wait()
+
+	g.Write([]byte("raw\n"))
+	require.NoError(t, g.Close())
+
+	read := func(name string) string {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		return string(b)
+	}
+	for i, id := range ids {
+		out := read(fmt.Sprintf("goroutine-%d.log", id))
+		assert.Equal(t, 1, strings.Count(out, "\n"))
+		assert.Contains(t, out, fmt.Sprintf("worker %d", i))
+	}
+	assert.Contains(t, read("named_worker.log"), "named")
+	assert.Equal(t, "raw\n", read("dabug.log"))
+
+	assert.ErrorIs(t, g.WriteLine(Line{}), os.ErrClosed)
+}
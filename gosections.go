@@ -0,0 +1,28 @@
+package dabug
+
+import "fmt"
+
+// groupByGoroutine splits lines by the goroutine that captured them, in the
+// order each goroutine first appears.
+func groupByGoroutine(lines []*Line) [][]*Line {
+	var groups [][]*Line
+	index := map[int64]int{}
+	for _, l := range lines {
+		i, ok := index[l.Goroutine]
+		if !ok {
+			i = len(groups)
+			index[l.Goroutine] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], l)
+	}
+	return groups
+}
+
+// goroutineName is the label of the goroutine that captured l, or its id.
+func goroutineName(l *Line) string {
+	if l.GoroutineLabel != "" {
+		return l.GoroutineLabel
+	}
+	return fmt.Sprintf("goroutine %d", l.Goroutine)
+}
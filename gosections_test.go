@@ -0,0 +1,62 @@
+package dabug
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupByGoroutine(t *testing.T) {
+	lines := []*Line{
+		{Goroutine: 2, Msg: "a"},
+		{Goroutine: 1, Msg: "b"},
+		{Goroutine: 2, Msg: "c"},
+	}
+	groups := groupByGoroutine(lines)
+	require.Len(t, groups, 2)
+	assert.Equal(t, []*Line{lines[0], lines[2]}, groups[0])
+	assert.Equal(t, []*Line{lines[1]}, groups[1])
+
+	assert.Equal(t, "goroutine 2", goroutineName(lines[0]))
+	assert.Equal(t, "worker", goroutineName(&Line{Goroutine: 2, GoroutineLabel: "worker"}))
+}
+
+func TestFlushPerGoroutine(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.LinePrefix("")
+	d.AutoFlush(false)
+
+	d.Msg("main one")
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer LabelGoroutine("worker")()
+		d.Msg("worker one")
+		d.Msg("worker two")
+	}()
+	wg.Wait()
+	d.Msg("main two")
+	d.Flush()
+
+	parts := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+	require.Len(t, parts, 8)
+	assert.Regexp(t, `^----- goroutine \d+ -----$`, parts[0])
+	assert.Contains(t, parts[1], "main one")
+	assert.Contains(t, parts[2], "main two")
+	assert.Equal(t, "----- worker -----", parts[4])
+	assert.Contains(t, parts[5], "worker one")
+	assert.Contains(t, parts[6], "worker two")
+	assert.Equal(t, "===== worker =====", parts[7])
+
+	// a single goroutine keeps the plain delimiters
+	sb.Reset()
+	d.Msg("alone")
+	d.Flush()
+	assert.True(t, strings.HasPrefix(sb.String(), sectionBeg+"\n"))
+}
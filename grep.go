@@ -0,0 +1,47 @@
+package dabug
+
+import "regexp"
+
+// Grep limits output to lines whose rendered text, including the header,
+// matches the regular expression pattern. Auto flushed lines are matched as
+// they are written and buffered lines when flushed, section delimiters are
+// written as long as one line of the section matches. An empty pattern
+// writes all lines again.
+func Grep(pattern string) error {
+	return defDabugger.Grep(pattern)
+}
+
+func (d *Dabugger) Grep(pattern string) error {
+	if pattern == "" {
+		d.grep.Store(nil)
+		return nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	d.grep.Store(re)
+	return nil
+}
+
+// grepMatch reports whether the plain text of l matches the Grep pattern.
+func (d *Dabugger) grepMatch(l *Line) bool {
+	re := d.grep.Load()
+	return re == nil || re.MatchString(lineStr(0, l, textOpts{}))
+}
+
+// grepLines returns the lines that match the Grep pattern.
+func (d *Dabugger) grepLines(lines []*Line) []*Line {
+	if d.grep.Load() == nil {
+		return lines
+	}
+
+	var kept []*Line
+	for _, l := range lines {
+		if d.grepMatch(l) {
+			kept = append(kept, l)
+		}
+	}
+	return kept
+}
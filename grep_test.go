@@ -0,0 +1,50 @@
+package dabug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrep(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.AutoFlush(false)
+
+	require.NoError(t, d.Grep(`cache (hit|miss)`))
+	d.Msg("cache hit")
+	d.Msg("db query")
+	d.Msg("cache miss")
+	d.Flush()
+
+	out := sb.String()
+	assert.Contains(t, out, "cache hit")
+	assert.Contains(t, out, "cache miss")
+	assert.NotContains(t, out, "db query")
+	assert.Equal(t, 4, strings.Count(out, "\n"))
+
+	sb.Reset()
+	d.Msg("db query")
+	d.Flush()
+	assert.Empty(t, sb.String())
+
+	d.AutoFlush(true)
+	d.Msg("db query")
+	d.Msg("cache hit")
+	assert.Equal(t, 1, strings.Count(sb.String(), "\n"))
+
+	// the header is matched too
+	sb.Reset()
+	require.NoError(t, d.Grep(`grep_test\.go`))
+	d.Msg("anything")
+	assert.Contains(t, sb.String(), "anything")
+
+	require.NoError(t, d.Grep(""))
+	d.Msg("db query")
+	assert.Contains(t, sb.String(), "db query")
+
+	assert.Error(t, d.Grep("(oops"))
+}
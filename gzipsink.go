@@ -0,0 +1,55 @@
+package dabug
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"sync"
+)
+
+// GzipWriter compresses output written to an underlying writer. Every write
+// is framed as a complete gzip member so that the stream stays readable,
+// ie: with `zcat`, up to the last completed write if the process dies.
+type GzipWriter struct {
+	w io.Writer
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+	gz  *gzip.Writer
+}
+
+// GzipSink wraps w with compression, use it with Writer or AddWriter.
+func GzipSink(w io.Writer) *GzipWriter {
+	g := &GzipWriter{w: w}
+	g.gz = gzip.NewWriter(&g.buf)
+	return g
+}
+
+func (g *GzipWriter) Write(p []byte) (int, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.buf.Reset()
+	g.gz.Reset(&g.buf)
+	if _, err := g.gz.Write(p); err != nil {
+		return 0, err
+	}
+	if err := g.gz.Close(); err != nil {
+		return 0, err
+	}
+
+	// write the member in one call so a partial member is less likely
+	if _, err := g.w.Write(g.buf.Bytes()); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close closes the underlying writer if it is an io.Closer.
+func (g *GzipWriter) Close() error {
+	if c, ok := g.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package dabug
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGzipSink(t *testing.T) {
+	buf := &bytes.Buffer{}
+	g := GzipSink(buf)
+
+	d := New()
+	d.Writer(g)
+	d.Msg("one")
+	d.Msg("two")
+	complete := buf.Len()
+	d.Msg("three")
+	require.NoError(t, g.Close())
+
+	read := func(b []byte) (string, error) {
+		r, err := gzip.NewReader(bytes.NewReader(b))
+		require.NoError(t, err)
+		out, err := io.ReadAll(r)
+		return string(out), err
+	}
+
+	out, err := read(buf.Bytes())
+	require.NoError(t, err)
+	assert.Contains(t, out, "one")
+	assert.Contains(t, out, "two")
+	assert.Contains(t, out, "three")
+
+	// a truncated stream still has the completed writes
+	out, err = read(buf.Bytes()[:complete+10])
+	assert.Error(t, err)
+	assert.Contains(t, out, "two")
+}
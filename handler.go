@@ -0,0 +1,100 @@
+package dabug
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// handlerRetain is the number of flushed lines kept by Handler when
+// retention has not been configured.
+const handlerRetain = 256
+
+type handlerLine struct {
+	jsonLine
+	Time time.Time `json:"time"`
+}
+
+type handlerPage struct {
+	Buffered []handlerLine `json:"buffered"`
+	History  []handlerLine `json:"history"`
+}
+
+// Handler returns an http.Handler that renders the lines waiting to be
+// flushed and the retained history as HTML, or JSON when requested with
+// `?format=json` or an `Accept: application/json` header. If retention is
+// disabled it is enabled, see Retain. Mount it like net/http/pprof, ie:
+//
+//	http.Handle("/debug/dabug", dabug.Handler())
+func Handler() http.Handler {
+	return defDabugger.Handler()
+}
+
+func (d *Dabugger) Handler() http.Handler {
+	d.linesMutex.Lock()
+	if d.retain == 0 {
+		d.retain = handlerRetain
+	}
+	d.linesMutex.Unlock()
+
+	return http.HandlerFunc(d.serveHTTP)
+}
+
+func (d *Dabugger) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	d.linesMutex.Lock()
+	page := handlerPage{
+		Buffered: handlerLines(d.lines),
+		History:  handlerLines(d.retained),
+	}
+	d.linesMutex.Unlock()
+
+	if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(page)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	handlerTmpl.Execute(w, page)
+}
+
+func handlerLines(lines []*Line) []handlerLine {
+	hls := make([]handlerLine, 0, len(lines))
+	for _, l := range lines {
+		hls = append(hls, handlerLine{newJSONLine(l), l.Time})
+	}
+	return hls
+}
+
+var handlerTmpl = template.Must(template.New("dabug").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>dabug</title>
+<style>
+body { font-family: monospace; }
+table { border-collapse: collapse; }
+td, th { padding: 0 1em; text-align: left; vertical-align: top; }
+td.msg { white-space: pre-wrap; }
+</style>
+</head>
+<body>
+{{define "lines"}}
+<table>
+<tr><th>time</th><th>source</th><th>context</th><th>message</th></tr>
+{{range .}}<tr>
+<td>{{.Time.Format "15:04:05.000000"}}</td>
+<td title="{{.Func}}">{{.File}}:{{.Line}}</td>
+<td>{{range .Context}}{{.Key}}:{{.Value}} {{end}}</td>
+<td class="msg">{{.Level}} {{.Msg}}</td>
+</tr>{{end}}
+</table>
+{{end}}
+<h2>Buffered ({{len .Buffered}})</h2>
+{{template "lines" .Buffered}}
+<h2>History ({{len .History}})</h2>
+{{template "lines" .History}}
+</body>
+</html>
+`))
@@ -0,0 +1,47 @@
+package dabug
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	d := New()
+	d.Writer(io.Discard)
+	h := d.Handler()
+	assert.Equal(t, handlerRetain, d.retain)
+
+	d.AddContext("k", "v")
+	d.Msg("<flushed>")
+	d.AutoFlush(false)
+	d.Warn("buffered")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/dabug", nil))
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "&lt;flushed&gt;")
+	assert.Contains(t, rec.Body.String(), "handler_test.go:")
+	assert.Contains(t, rec.Body.String(), "k:v")
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/dabug?format=json", nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var page struct {
+		Buffered []map[string]any
+		History  []map[string]any
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+	require.Len(t, page.Buffered, 1)
+	require.Len(t, page.History, 1)
+	assert.Equal(t, "buffered", page.Buffered[0]["msg"])
+	assert.Equal(t, "warn", page.Buffered[0]["level"])
+	assert.Equal(t, "<flushed>", page.History[0]["msg"])
+	assert.NotZero(t, page.History[0]["time"])
+}
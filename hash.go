@@ -0,0 +1,34 @@
+package dabug
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+)
+
+// Hash appends a line with a stable hash of the content of v, ie:
+// `hash config = 9f86d081884c7d65 (1.2 KiB)`. Values are serialized in Go
+// syntax with sorted map keys and no depth or element limits before hashing,
+// so equal values hash the same between runs and points in the program.
+// Channels, funcs, and unsafe pointers only hash whether they are nil, and
+// maps keyed by pointers or channels are still ordered by address so their
+// hash can change between runs. Hash returns "" when output is disabled.
+func Hash(name string, v any) string {
+	return defDabugger.Hash(name, v)
+}
+
+func (d *Dabugger) Hash(name string, v any) string {
+	if !d.enabled() {
+		return ""
+	}
+
+	p := &printer{visiting: map[visit]bool{}, stable: true}
+	p.value(reflect.ValueOf(v), 0)
+
+	sum := sha256.Sum256([]byte(p.sb.String()))
+	h := hex.EncodeToString(sum[:8])
+	d.appendMsg(fmt.Sprintf("hash %s = %s (%s)", name, h, Bytes(p.sb.Len())))
+
+	return h
+}
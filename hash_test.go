@@ -0,0 +1,56 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHash(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.MaxElems(2)
+
+	big := make(map[int]string)
+	for i := 0; i < 100; i++ {
+		big[i] = "v"
+	}
+
+	h1 := d.Hash("big", big)
+	big2 := make(map[int]string)
+	for i := 99; i >= 0; i-- {
+		big2[i] = "v"
+	}
+	assert.Equal(t, h1, d.Hash("big", big2))
+
+	big2[50] = "changed"
+	assert.NotEqual(t, h1, d.Hash("big", big2))
+
+	assert.Len(t, h1, 16)
+
+	lines := rec.Lines()
+	require.Len(t, lines, 3)
+	assert.Regexp(t, `^hash big = [0-9a-f]{16} \(804 B\)$`, lines[0].Msg)
+}
+
+func TestHashStable(t *testing.T) {
+	type node struct {
+		Next *node
+		Ch   chan int
+		Fn   func()
+	}
+
+	d, _ := newMsgDabugger()
+	hash := func() string {
+		n := &node{Ch: make(chan int), Fn: func() {}}
+		n.Next = n
+		return d.Hash("n", n)
+	}
+	assert.Equal(t, hash(), hash())
+	assert.NotEqual(t, hash(), d.Hash("n", &node{}))
+
+	d.Mute()
+	assert.Empty(t, d.Hash("n", &node{}))
+}
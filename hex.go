@@ -0,0 +1,24 @@
+package dabug
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Hex appends a line with an offset/hex/ASCII dump of b.
+func Hex(name string, b []byte) {
+	defDabugger.Hex(name, b)
+}
+
+func (d *Dabugger) Hex(name string, b []byte) {
+	if !d.enabled() {
+		return
+	}
+
+	msg := fmt.Sprintf("%s (%d bytes)", name, len(b))
+	if len(b) > 0 {
+		msg += "\n" + strings.TrimSuffix(hex.Dump(b), "\n")
+	}
+	d.appendMsg(msg)
+}
@@ -0,0 +1,18 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHex(t *testing.T) {
+	d, sb := newMsgDabugger()
+	d.Hex("payload", []byte("hello, dabug!\x00\x01\xff world"))
+	d.Hex("empty", nil)
+
+	assert.Equal(t, "payload (22 bytes)\n"+
+		"00000000  68 65 6c 6c 6f 2c 20 64  61 62 75 67 21 00 01 ff  |hello, dabug!...|\n"+
+		"00000010  20 77 6f 72 6c 64                                 | world|\n"+
+		"empty (0 bytes)\n", sb.String())
+}
@@ -0,0 +1,106 @@
+package dabug
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Bytes is a byte count that is rendered with binary units, ie: `3.4 MiB`.
+type Bytes int64
+
+func (b Bytes) String() string {
+	const unit = 1024
+	if b < unit && b > -unit {
+		return fmt.Sprintf("%d B", int64(b))
+	}
+
+	n, exp := float64(b), 0
+	for n >= unit*unit || n <= -unit*unit {
+		n /= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", n/unit, "KMGTPE"[exp])
+}
+
+// Humanize renders durations, Bytes, and large integers passed to Msg and
+// Objs in a human friendly form, ie: `1.2s`, `3.4 MiB`, `1,234,567`.
+func Humanize(enabled bool) {
+	defDabugger.Humanize(enabled)
+}
+
+func (d *Dabugger) Humanize(enabled bool) {
+	d.humanize = enabled
+}
+
+// humanArgs wraps the Msg arguments that have a human friendly form.
+func (d *Dabugger) humanArgs(v []any) []any {
+	if !d.humanize {
+		return v
+	}
+
+	args := make([]any, len(v))
+	for i, a := range v {
+		if _, ok := humanStr(a); ok {
+			a = humanArg{a}
+		}
+		args[i] = a
+	}
+
+	return args
+}
+
+// humanArg renders the wrapped value in its human friendly form for the
+// %v, %s, and %d verbs, all other verbs render the value as is.
+type humanArg struct {
+	v any
+}
+
+func (h humanArg) Format(f fmt.State, verb rune) {
+	switch verb {
+	case 'v', 's', 'd':
+		s, _ := humanStr(h.v)
+		fmt.Fprintf(f, fmt.FormatString(f, 's'), s)
+	default:
+		fmt.Fprintf(f, fmt.FormatString(f, verb), h.v)
+	}
+}
+
+// humanStr renders v in a human friendly form if it has one.
+func humanStr(v any) (string, bool) {
+	switch t := v.(type) {
+	case time.Duration:
+		return fmtDuration(t), true
+	case Bytes:
+		return t.String(), true
+	case int:
+		return groupDigits(strconv.FormatInt(int64(t), 10)), true
+	case int32:
+		return groupDigits(strconv.FormatInt(int64(t), 10)), true
+	case int64:
+		return groupDigits(strconv.FormatInt(t, 10)), true
+	case uint:
+		return groupDigits(strconv.FormatUint(uint64(t), 10)), true
+	case uint32:
+		return groupDigits(strconv.FormatUint(uint64(t), 10)), true
+	case uint64:
+		return groupDigits(strconv.FormatUint(t, 10)), true
+	}
+
+	return "", false
+}
+
+// groupDigits inserts thousands separators into a formatted integer.
+func groupDigits(s string) string {
+	sign := ""
+	if s[0] == '-' {
+		sign, s = "-", s[1:]
+	}
+
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+
+	return sign + s
+}
@@ -0,0 +1,36 @@
+package dabug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBytes(t *testing.T) {
+	assert.Equal(t, "0 B", Bytes(0).String())
+	assert.Equal(t, "1023 B", Bytes(1023).String())
+	assert.Equal(t, "1.0 KiB", Bytes(1024).String())
+	assert.Equal(t, "3.4 MiB", Bytes(3565158).String())
+	assert.Equal(t, "-2.0 GiB", Bytes(-2*1024*1024*1024).String())
+}
+
+func TestGroupDigits(t *testing.T) {
+	assert.Equal(t, "1", groupDigits("1"))
+	assert.Equal(t, "123", groupDigits("123"))
+	assert.Equal(t, "1,234", groupDigits("1234"))
+	assert.Equal(t, "1,234,567", groupDigits("1234567"))
+	assert.Equal(t, "-123,456", groupDigits("-123456"))
+}
+
+func TestHumanize(t *testing.T) {
+	d, sb := newMsgDabugger()
+	d.Msg("%d %v", 1234567, 1200*time.Millisecond)
+	d.Humanize(true)
+	d.Msg("%d %v %s %x %5d|", 1234567, 1200*time.Millisecond, Bytes(2048), 255, 12)
+	d.Objs(1234567, 1200*time.Millisecond, Bytes(2048), "str")
+
+	assert.Equal(t, "1234567 1.2s\n"+
+		"1,234,567 1.2s 2.0 KiB ff    12|\n"+
+		`[0] 1,234,567, [1] 1.2s, [2] 2.0 KiB, [3] "str"`+"\n", sb.String())
+}
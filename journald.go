@@ -0,0 +1,103 @@
+//go:build linux && journald
+
+package dabug
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// journalSocket is where journald listens for native protocol datagrams.
+const journalSocket = "/run/systemd/journal/socket"
+
+// journalPriorities maps levels to syslog priorities, lines without a level
+// are logged at debug priority.
+var journalPriorities = map[Level]int{
+	LevelNone: 7,
+	LevelOk:   6,
+	LevelWarn: 4,
+	LevelErr:  3,
+}
+
+// JournalWriter is a LineWriter that sends each line to journald using the
+// native protocol, with the source recorded in the CODE_FILE, CODE_LINE and
+// CODE_FUNC fields and the level mapped to the entry priority. It is only
+// available when built with the journald tag.
+type JournalWriter struct {
+	conn       io.WriteCloser
+	identifier string
+}
+
+// JournalSink connects to the local journald, identifier is recorded as the
+// SYSLOG_IDENTIFIER of each entry. Use it with Writer or AddWriter.
+func JournalSink(identifier string) (*JournalWriter, error) {
+	return journalSink(journalSocket, identifier)
+}
+
+func journalSink(path, identifier string) (*JournalWriter, error) {
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+
+	return &JournalWriter{conn: conn, identifier: identifier}, nil
+}
+
+func (j *JournalWriter) WriteLine(l Line) error {
+	fields := [][2]string{
+		{"MESSAGE", l.Msg},
+		{"PRIORITY", strconv.Itoa(journalPriorities[l.Level])},
+		{"CODE_FILE", l.File},
+		{"CODE_LINE", strconv.Itoa(l.Line)},
+		{"CODE_FUNC", l.Function},
+	}
+	if len(l.Context) > 0 {
+		fields = append(fields, [2]string{"DABUG_CONTEXT", l.Context.String()})
+	}
+
+	return j.send(fields)
+}
+
+// Write sends text that did not come from a Dabugger as a single entry.
+func (j *JournalWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	if err := j.send([][2]string{{"MESSAGE", msg}, {"PRIORITY", "7"}}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (j *JournalWriter) send(fields [][2]string) error {
+	if j.identifier != "" {
+		fields = append(fields, [2]string{"SYSLOG_IDENTIFIER", j.identifier})
+	}
+
+	buf := bytes.Buffer{}
+	for _, f := range fields {
+		journalField(&buf, f[0], f[1])
+	}
+
+	_, err := j.conn.Write(buf.Bytes())
+	return err
+}
+
+// journalField encodes a field, values containing newlines use the length
+// prefixed binary form.
+func journalField(buf *bytes.Buffer, key, value string) {
+	if !strings.Contains(value, "\n") {
+		buf.WriteString(key + "=" + value + "\n")
+		return
+	}
+
+	buf.WriteString(key + "\n")
+	binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value + "\n")
+}
+
+func (j *JournalWriter) Close() error {
+	return j.conn.Close()
+}
@@ -0,0 +1,59 @@
+//go:build linux && journald
+
+package dabug
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournalWriter(t *testing.T) {
+	rec := &entryRecorder{}
+	d := New()
+	d.Writer(&JournalWriter{conn: rec, identifier: "app"})
+	d.LinePrefix("")
+
+	d.Msg("hello")
+	d.Warn("careful")
+	d.AddContext("id", "1")
+	d.Err("multi\nline")
+
+	require.Len(t, rec.entries, 3)
+	assert.Contains(t, rec.entries[0], "MESSAGE=hello\n")
+	assert.Contains(t, rec.entries[0], "PRIORITY=7\n")
+	assert.Contains(t, rec.entries[0], "CODE_FILE=journald_test.go\n")
+	assert.Contains(t, rec.entries[0], "CODE_FUNC=github.com/dcaravel/dabug.TestJournalWriter\n")
+	assert.Contains(t, rec.entries[0], "SYSLOG_IDENTIFIER=app\n")
+	assert.Contains(t, rec.entries[1], "PRIORITY=4\n")
+	assert.Contains(t, rec.entries[2], "PRIORITY=3\n")
+	assert.Contains(t, rec.entries[2], "DABUG_CONTEXT=id:1\n")
+
+	size := make([]byte, 8)
+	binary.LittleEndian.PutUint64(size, uint64(len("multi\nline")))
+	assert.Contains(t, rec.entries[2], "MESSAGE\n"+string(size)+"multi\nline\n")
+}
+
+func TestJournalSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	l, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	defer l.Close()
+
+	j, err := journalSink(path, "app")
+	require.NoError(t, err)
+	defer j.Close()
+
+	_, err = j.Write([]byte("raw\n"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 1024)
+	n, err := l.Read(buf)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(buf[:n], []byte("MESSAGE=raw\nPRIORITY=7\n")))
+}
@@ -0,0 +1,37 @@
+package dabug
+
+// MsgFunc appends a line with the message returned by fn, fn is only called
+// if the line is written so expensive formatting can be left in hot paths.
+func MsgFunc(fn func() string) {
+	defDabugger.MsgFunc(fn)
+}
+
+func (d *Dabugger) MsgFunc(fn func() string) {
+	if !d.enabled() {
+		return
+	}
+	d.appendLine(&Line{
+		Source: d.getSource(),
+		lazy: func() string {
+			return d.truncate(fn())
+		},
+	})
+}
+
+// ObjsFunc appends a line with the things returned by fn printed as with
+// Objs, fn is only called if the line is written.
+func ObjsFunc(fn func() []any) {
+	defDabugger.ObjsFunc(fn)
+}
+
+func (d *Dabugger) ObjsFunc(fn func() []any) {
+	if !d.enabled() {
+		return
+	}
+	d.appendLine(&Line{
+		Source: d.getSource(),
+		lazy: func() string {
+			return d.objsStr(fn())
+		},
+	})
+}
@@ -0,0 +1,33 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgFunc(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.MaxValueLen(8)
+
+	calls := 0
+	d.MsgFunc(func() string {
+		calls++
+		return "computed message"
+	})
+	d.ObjsFunc(func() []any {
+		calls++
+		return []any{1, "a"}
+	})
+
+	assert.Equal(t, 2, calls)
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "computed… (16 bytes)", lines[0].Msg)
+	assert.Equal(t, "lazy_test.go", lines[0].File)
+	assert.Equal(t, `[0] 1, [1] "a"`, lines[1].Msg)
+}
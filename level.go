@@ -0,0 +1,81 @@
+package dabug
+
+import "fmt"
+
+// Level marks lines that should stand out from the rest of a section.
+type Level int
+
+const (
+	LevelNone Level = iota
+	LevelOk
+	LevelWarn
+	LevelErr
+)
+
+var levels = map[Level]struct {
+	name   string
+	marker string
+	color  string
+}{
+	LevelOk:   {"ok", "✓", colorOk},
+	LevelWarn: {"warn", "⚠", colorWarn},
+	LevelErr:  {"err", "✗", colorErr},
+}
+
+func (l Level) String() string {
+	return levels[l].name
+}
+
+// Marker returns the symbol printed before messages of this level.
+func (l Level) Marker() string {
+	return levels[l].marker
+}
+
+// levelStr renders the marker of l including the trailing space, or
+// nothing if l has no level.
+func levelStr(l *Line, color bool) string {
+	lvl, ok := levels[l.Level]
+	if !ok {
+		return ""
+	}
+	if color {
+		return colorize(lvl.color, lvl.marker) + " "
+	}
+	return lvl.marker + " "
+}
+
+// Ok appends a message marked with ✓.
+func Ok(format string, v ...any) {
+	defDabugger.Ok(format, v...)
+}
+
+func (d *Dabugger) Ok(format string, v ...any) {
+	if !d.enabled() {
+		return
+	}
+	d.appendLevel(LevelOk, d.truncate(fmt.Sprintf(format, d.humanArgs(v)...)))
+}
+
+// Warn appends a message marked with ⚠.
+func Warn(format string, v ...any) {
+	defDabugger.Warn(format, v...)
+}
+
+func (d *Dabugger) Warn(format string, v ...any) {
+	if !d.enabled() {
+		return
+	}
+	d.appendLevel(LevelWarn, d.truncate(fmt.Sprintf(format, d.humanArgs(v)...)))
+}
+
+// Err appends a message marked with ✗.
+func Err(format string, v ...any) {
+	defDabugger.Err(format, v...)
+}
+
+func (d *Dabugger) Err(format string, v ...any) {
+	if !d.enabled() {
+		return
+	}
+	d.appendLevel(LevelErr, d.truncate(fmt.Sprintf(format, d.humanArgs(v)...)))
+}
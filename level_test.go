@@ -0,0 +1,42 @@
+package dabug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevels(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.AutoFlush(false)
+	d.Msg("plain")
+	d.Ok("ok %d", 1)
+	d.Warn("warn %d", 2)
+	d.Err("err %d", 3)
+	d.Flush()
+
+	parts := strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 7)
+	assert.True(t, strings.HasSuffix(parts[1], "- plain"))
+	assert.True(t, strings.HasSuffix(parts[2], "- ✓ ok 1"))
+	assert.True(t, strings.HasSuffix(parts[3], "- ⚠ warn 2"))
+	assert.True(t, strings.HasSuffix(parts[4], "- ✗ err 3"))
+
+	sb.Reset()
+	d.Format(FormatJSON)
+	d.Warn("warn")
+	d.Flush()
+	assert.Contains(t, sb.String(), `"level":"warn"`)
+
+	sb.Reset()
+	d.Format(FormatLogfmt)
+	d.Msg("plain")
+	d.Err("err")
+	d.Flush()
+	assert.NotContains(t, strings.Split(sb.String(), "\n")[0], "level=")
+	assert.Contains(t, strings.Split(sb.String(), "\n")[1], "level=err")
+}
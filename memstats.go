@@ -0,0 +1,75 @@
+package dabug
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// MemStats writes a line with the heap size, heap objects, number of GCs,
+// and total GC pause time, ie:
+// `mem: heap 12.3 MiB, 1,234 objects, 5 GCs, 1.2ms paused`.
+func MemStats() {
+	defDabugger.MemStats()
+}
+
+func (d *Dabugger) MemStats() {
+	if !d.enabled() {
+		return
+	}
+
+	m := d.readMemStats()
+	d.appendMsg(fmt.Sprintf("mem: heap %s, %s objects, %d GCs, %s paused",
+		Bytes(m.HeapAlloc), groupDigits(fmt.Sprint(m.HeapObjects)), m.NumGC,
+		fmtDuration(time.Duration(m.PauseTotalNs))))
+}
+
+// MemDelta writes the change in the MemStats values since the previous
+// MemStats or MemDelta call, ie:
+// `mem delta: heap +1.2 MiB, +100 objects, +2 GCs, +300µs paused`.
+func MemDelta() {
+	defDabugger.MemDelta()
+}
+
+func (d *Dabugger) MemDelta() {
+	if !d.enabled() {
+		return
+	}
+
+	d.statsMutex.Lock()
+	prev := d.memStats
+	d.statsMutex.Unlock()
+
+	if prev == nil {
+		d.MemStats()
+		return
+	}
+
+	m := d.readMemStats()
+	d.appendMsg(fmt.Sprintf("mem delta: heap %s, %s objects, %s GCs, +%s paused",
+		signed(Bytes(int64(m.HeapAlloc)-int64(prev.HeapAlloc)).String(), m.HeapAlloc < prev.HeapAlloc),
+		signed(groupDigits(fmt.Sprint(int64(m.HeapObjects)-int64(prev.HeapObjects))), m.HeapObjects < prev.HeapObjects),
+		signed(fmt.Sprint(m.NumGC-prev.NumGC), false),
+		fmtDuration(time.Duration(m.PauseTotalNs-prev.PauseTotalNs))))
+}
+
+// readMemStats reads the current stats and records them as the snapshot
+// for the next MemDelta.
+func (d *Dabugger) readMemStats() *runtime.MemStats {
+	m := &runtime.MemStats{}
+	runtime.ReadMemStats(m)
+
+	d.statsMutex.Lock()
+	d.memStats = m
+	d.statsMutex.Unlock()
+
+	return m
+}
+
+// signed prefixes s with + unless it is negative.
+func signed(s string, negative bool) string {
+	if negative {
+		return s
+	}
+	return "+" + s
+}
@@ -0,0 +1,36 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var memSink [][]byte
+
+func TestMemStats(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	d.MemDelta()
+	for i := 0; i < 100; i++ {
+		memSink = append(memSink, make([]byte, 64*1024))
+	}
+	d.MemDelta()
+	d.MemStats()
+	memSink = nil
+
+	lines := rec.Lines()
+	require.Len(t, lines, 3)
+	assert.Regexp(t, `^mem: heap [\d.]+ [KMG]?i?B, [\d,]+ objects, \d+ GCs, \S+ paused$`, lines[0].Msg)
+	assert.Regexp(t, `^mem delta: heap \+[\d.]+ MiB, [+-][\d,]+ objects, \+\d+ GCs, \+\S+ paused$`, lines[1].Msg)
+	assert.Regexp(t, `^mem: heap `, lines[2].Msg)
+	assert.Equal(t, "memstats_test.go", lines[1].File)
+}
+
+func TestSigned(t *testing.T) {
+	assert.Equal(t, "+1", signed("1", false))
+	assert.Equal(t, "-1", signed("-1", true))
+}
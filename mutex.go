@@ -0,0 +1,248 @@
+package dabug
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// lockHolder is the call site that acquired a lock and when.
+type lockHolder struct {
+	src Source
+	at  time.Time
+}
+
+func (h lockHolder) String() string {
+	return fmt.Sprintf("%s for %s", h.src, fmtDuration(time.Since(h.at)))
+}
+
+// Mutex is a drop-in sync.Mutex that writes a line when acquiring the lock
+// waited at least Threshold, with where the lock is held, and when the lock
+// was held for at least Threshold, ie:
+//
+//	mutex cache: waited 12ms, held by cache.go:40 for 15ms
+//	mutex cache: held 27ms by cache.go:40
+//
+// A zero Threshold writes every contended wait and every hold.
+type Mutex struct {
+	Name      string
+	Threshold time.Duration
+	// Dabugger writes the lines, the default Dabugger is used if nil
+	Dabugger *Dabugger
+
+	mu sync.Mutex
+	// holderMu guards holder, which is only set while instrumented
+	holderMu sync.Mutex
+	holder   *lockHolder
+}
+
+func (m *Mutex) dabugger() *Dabugger {
+	if m.Dabugger != nil {
+		return m.Dabugger
+	}
+	return defDabugger
+}
+
+func (m *Mutex) Lock() {
+	d := m.dabugger()
+	if !d.enabled() {
+		m.mu.Lock()
+		return
+	}
+
+	src := d.getSource()
+	if !m.mu.TryLock() {
+		m.holderMu.Lock()
+		holder := m.holder
+		m.holderMu.Unlock()
+
+		start := time.Now()
+		m.mu.Lock()
+		d.lockWaited("mutex", m.Name, m.Threshold, time.Since(start), holder)
+	}
+
+	m.holderMu.Lock()
+	m.holder = &lockHolder{src: src, at: time.Now()}
+	m.holderMu.Unlock()
+}
+
+func (m *Mutex) TryLock() bool {
+	if !m.mu.TryLock() {
+		return false
+	}
+
+	if d := m.dabugger(); d.enabled() {
+		m.holderMu.Lock()
+		m.holder = &lockHolder{src: d.getSource(), at: time.Now()}
+		m.holderMu.Unlock()
+	}
+	return true
+}
+
+func (m *Mutex) Unlock() {
+	m.holderMu.Lock()
+	holder := m.holder
+	m.holder = nil
+	m.holderMu.Unlock()
+
+	if holder != nil {
+		m.dabugger().lockHeld("mutex", m.Name, m.Threshold, *holder)
+	}
+	m.mu.Unlock()
+}
+
+// RWMutex is a drop-in sync.RWMutex that writes lines as Mutex does, for
+// readers as well as writers.
+type RWMutex struct {
+	Name      string
+	Threshold time.Duration
+	// Dabugger writes the lines, the default Dabugger is used if nil
+	Dabugger *Dabugger
+
+	mu sync.RWMutex
+	// holderMu guards writer and readers, readers are in RLock order
+	holderMu sync.Mutex
+	writer   *lockHolder
+	readers  []readHolder
+}
+
+// readHolder is a read lock held on a RWMutex and the goroutine that
+// acquired it, which is not necessarily the one that releases it.
+type readHolder struct {
+	lockHolder
+	goid int64
+}
+
+func (m *RWMutex) dabugger() *Dabugger {
+	if m.Dabugger != nil {
+		return m.Dabugger
+	}
+	return defDabugger
+}
+
+func (m *RWMutex) Lock() {
+	d := m.dabugger()
+	if !d.enabled() {
+		m.mu.Lock()
+		return
+	}
+
+	src := d.getSource()
+	if !m.mu.TryLock() {
+		holders := m.holders()
+		start := time.Now()
+		m.mu.Lock()
+		d.lockWaited("rwmutex", m.Name, m.Threshold, time.Since(start), holders...)
+	}
+
+	m.holderMu.Lock()
+	m.writer = &lockHolder{src: src, at: time.Now()}
+	m.holderMu.Unlock()
+}
+
+func (m *RWMutex) Unlock() {
+	m.holderMu.Lock()
+	holder := m.writer
+	m.writer = nil
+	m.holderMu.Unlock()
+
+	if holder != nil {
+		m.dabugger().lockHeld("rwmutex", m.Name, m.Threshold, *holder)
+	}
+	m.mu.Unlock()
+}
+
+func (m *RWMutex) RLock() {
+	d := m.dabugger()
+	if !d.enabled() {
+		m.mu.RLock()
+		return
+	}
+
+	src := d.getSource()
+	if !m.mu.TryRLock() {
+		holders := m.holders()
+		start := time.Now()
+		m.mu.RLock()
+		d.lockWaited("rwmutex", m.Name+" read", m.Threshold, time.Since(start), holders...)
+	}
+
+	m.holderMu.Lock()
+	m.readers = append(m.readers, readHolder{lockHolder{src: src, at: time.Now()}, goid()})
+	m.holderMu.Unlock()
+}
+
+func (m *RWMutex) RUnlock() {
+	id := goid()
+	m.holderMu.Lock()
+	var holder lockHolder
+	ok := len(m.readers) > 0
+	if ok {
+		// release the last read lock of this goroutine, or the oldest one as
+		// a read lock can be released by another goroutine than its holder
+		i := 0
+		for j := len(m.readers) - 1; j >= 0; j-- {
+			if m.readers[j].goid == id {
+				i = j
+				break
+			}
+		}
+		holder = m.readers[i].lockHolder
+		m.readers = slices.Delete(m.readers, i, i+1)
+	}
+	m.holderMu.Unlock()
+
+	if ok {
+		m.dabugger().lockHeld("rwmutex", m.Name+" read", m.Threshold, holder)
+	}
+	m.mu.RUnlock()
+}
+
+// holders returns the current writer or readers.
+func (m *RWMutex) holders() []*lockHolder {
+	m.holderMu.Lock()
+	defer m.holderMu.Unlock()
+
+	if m.writer != nil {
+		return []*lockHolder{m.writer}
+	}
+	var holders []*lockHolder
+	for _, r := range m.readers {
+		h := r.lockHolder
+		holders = append(holders, &h)
+	}
+	return holders
+}
+
+// lockWaited writes how long acquiring the lock name waited if it waited
+// at least threshold.
+func (d *Dabugger) lockWaited(kind, name string, threshold, waited time.Duration, holders ...*lockHolder) {
+	if waited < threshold {
+		return
+	}
+
+	msg := fmt.Sprintf("%s %s: waited %s", kind, name, fmtDuration(waited))
+	for i, h := range holders {
+		if h == nil {
+			continue
+		}
+		if i == 0 {
+			msg += ", held by "
+		} else {
+			msg += ", "
+		}
+		msg += h.String()
+	}
+	d.appendMsg(msg)
+}
+
+// lockHeld writes how long h held the lock name if it was at least
+// threshold.
+func (d *Dabugger) lockHeld(kind, name string, threshold time.Duration, h lockHolder) {
+	held := time.Since(h.at)
+	if held < threshold || !d.enabled() {
+		return
+	}
+	d.appendMsg(fmt.Sprintf("%s %s: held %s by %s", kind, name, fmtDuration(held), h.src))
+}
@@ -0,0 +1,109 @@
+package dabug
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMutex(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	m := &Mutex{Name: "cache", Threshold: 10 * time.Millisecond, Dabugger: d}
+	m.Lock()
+	m.Unlock()
+	assert.Empty(t, rec.Lines())
+
+	m.Lock()
+	locked := make(chan struct{})
+	go func() {
+		m.Lock()
+		close(locked)
+		m.Unlock()
+	}()
+	time.Sleep(30 * time.Millisecond)
+	m.Unlock()
+	<-locked
+
+	require.True(t, m.TryLock())
+	assert.False(t, m.TryLock())
+	m.Unlock()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^mutex cache: held \S+ by mutex_test.go:\d+$`, lines[0].Msg)
+	assert.Regexp(t, `^mutex cache: waited \S+, held by mutex_test.go:\d+ for \S+$`, lines[1].Msg)
+	assert.Equal(t, "mutex_test.go", lines[1].File)
+}
+
+func TestRWMutex(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	m := &RWMutex{Name: "config", Dabugger: d}
+	m.RLock()
+	m.RLock()
+	m.RUnlock()
+	m.RUnlock()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^rwmutex config read: held \S+ by mutex_test.go:\d+$`, lines[0].Msg)
+
+	rec.Reset()
+	m.Threshold = 10 * time.Millisecond
+	m.RLock()
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		m.Lock()
+		m.Unlock()
+	}()
+	time.Sleep(30 * time.Millisecond)
+	m.RUnlock()
+	wg.Wait()
+
+	lines = rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^rwmutex config read: held \S+ by mutex_test.go:\d+$`, lines[0].Msg)
+	assert.Regexp(t, `^rwmutex config: waited \S+, held by mutex_test.go:\d+ for \S+$`, lines[1].Msg)
+
+	var _ sync.Locker = &Mutex{}
+	var _ sync.Locker = &RWMutex{}
+}
+
+func TestRWMutexCrossGoroutineRUnlock(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	m := &RWMutex{Name: "config", Dabugger: d}
+	m.RLock()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.RUnlock()
+	}()
+	<-done
+
+	assert.Empty(t, m.holders())
+	require.Len(t, rec.Lines(), 1)
+	assert.Regexp(t, `^rwmutex config read: held \S+ by mutex_test.go:\d+$`, rec.Lines()[0].Msg)
+
+	m.RLock()
+	m.RLock()
+	go func() {
+		m.RUnlock()
+		m.RUnlock()
+	}()
+	m.Lock()
+	assert.Empty(t, m.readers)
+	m.Unlock()
+}
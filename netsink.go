@@ -0,0 +1,102 @@
+package dabug
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	netDialTimeout = time.Second
+	netMinBackoff  = 100 * time.Millisecond
+	netMaxBackoff  = 30 * time.Second
+)
+
+// errNetBackoff is returned for writes while waiting to reconnect.
+var errNetBackoff = errors.New("dabug: waiting to reconnect")
+
+// NetWriter streams output to a remote collector. Connections are made on
+// demand and are retried with an exponential backoff, output written while
+// disconnected is dropped.
+type NetWriter struct {
+	network string
+	addr    string
+
+	mu      sync.Mutex
+	conn    net.Conn
+	backoff time.Duration
+	retryAt time.Time
+	closed  bool
+
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// NetSink creates a writer that streams to addr, network is any network
+// supported by net.Dial such as "tcp" or "udp". Use it with Writer or
+// AddWriter.
+func NetSink(network, addr string) *NetWriter {
+	return &NetWriter{
+		network:    network,
+		addr:       addr,
+		minBackoff: netMinBackoff,
+		maxBackoff: netMaxBackoff,
+	}
+}
+
+func (n *NetWriter) Write(p []byte) (int, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.closed {
+		return 0, net.ErrClosed
+	}
+
+	if n.conn == nil {
+		if err := n.connect(); err != nil {
+			return 0, err
+		}
+	}
+
+	written, err := n.conn.Write(p)
+	if err != nil {
+		// reconnect on the next write
+		n.conn.Close()
+		n.conn = nil
+	}
+	return written, err
+}
+
+func (n *NetWriter) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.closed = true
+	if n.conn == nil {
+		return nil
+	}
+
+	err := n.conn.Close()
+	n.conn = nil
+	return err
+}
+
+// connect dials the collector unless still backing off from a failure,
+// must be called with mu held.
+func (n *NetWriter) connect() error {
+	if time.Now().Before(n.retryAt) {
+		return errNetBackoff
+	}
+
+	conn, err := net.DialTimeout(n.network, n.addr, netDialTimeout)
+	if err != nil {
+		n.backoff = min(max(n.backoff*2, n.minBackoff), n.maxBackoff)
+		n.retryAt = time.Now().Add(n.backoff)
+		return err
+	}
+
+	n.conn = conn
+	n.backoff = 0
+	return nil
+}
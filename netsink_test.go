@@ -0,0 +1,77 @@
+package dabug
+
+import (
+	"bufio"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetSink(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	lines := make(chan string, 10)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				s := bufio.NewScanner(conn)
+				for s.Scan() {
+					lines <- s.Text()
+				}
+			}()
+		}
+	}()
+
+	w := NetSink("tcp", ln.Addr().String())
+	defer w.Close()
+
+	d := New()
+	d.Writer(w)
+	d.Msg("over the wire")
+
+	select {
+	case l := <-lines:
+		assert.Contains(t, l, "over the wire")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for line")
+	}
+
+	require.NoError(t, w.Close())
+	_, err = w.Write([]byte("closed"))
+	assert.ErrorIs(t, err, net.ErrClosed)
+}
+
+func TestNetSinkBackoff(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	w := NetSink("tcp", addr)
+	w.minBackoff = 40 * time.Minute
+	w.maxBackoff = time.Hour
+	defer w.Close()
+
+	_, err = w.Write([]byte("fail"))
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, errNetBackoff)
+	assert.Equal(t, 40*time.Minute, w.backoff)
+
+	_, err = w.Write([]byte("backoff"))
+	assert.ErrorIs(t, err, errNetBackoff)
+
+	w.retryAt = time.Time{}
+	_, err = w.Write([]byte("fail again"))
+	require.Error(t, err)
+	assert.Equal(t, time.Hour, w.backoff)
+}
@@ -0,0 +1,178 @@
+package dabug
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Stringers makes Objs render values that implement error or fmt.Stringer
+// using those methods instead of Go syntax.
+func Stringers(enabled bool) {
+	defDabugger.Stringers(enabled)
+}
+
+func (d *Dabugger) Stringers(enabled bool) {
+	d.stringers = enabled
+}
+
+// MaxValueLen truncates messages and each rendered Objs value that is
+// longer than n bytes, set n to 0 to disable truncation.
+func MaxValueLen(n int) {
+	defDabugger.MaxValueLen(n)
+}
+
+func (d *Dabugger) MaxValueLen(n int) {
+	d.maxValueLen = max(n, 0)
+}
+
+// objStr renders a single thing for Objs.
+func (d *Dabugger) objStr(t any) string {
+	if d.humanize {
+		if s, ok := humanStr(t); ok {
+			return d.truncate(s)
+		}
+	}
+	if d.stringers {
+		if s, ok := stringerStr(t); ok {
+			return d.truncate(s)
+		}
+	}
+
+	if s, ok := jsonStr(t); ok {
+		return d.truncate(s)
+	}
+
+	return d.truncate(d.prettyStr(t))
+}
+
+// jsonStr renders strings and byte slices holding a JSON object or array as
+// indented JSON.
+func jsonStr(t any) (string, bool) {
+	var b []byte
+	switch v := t.(type) {
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		return "", false
+	}
+
+	trimmed := bytes.TrimSpace(b)
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') || !json.Valid(trimmed) {
+		return "", false
+	}
+
+	buf := bytes.Buffer{}
+	if err := json.Indent(&buf, trimmed, "", indent); err != nil {
+		return "", false
+	}
+	return buf.String(), true
+}
+
+// truncate shortens s to maxValueLen bytes noting the original size.
+func (d *Dabugger) truncate(s string) string {
+	if d.maxValueLen == 0 || len(s) <= d.maxValueLen {
+		return s
+	}
+
+	cut := d.maxValueLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+
+	return fmt.Sprintf("%s… (%d bytes)", s[:cut], len(s))
+}
+
+// stringerStr renders t using its Error or String method, a panicking
+// method (ie: on a nil receiver) is reported as not ok.
+func stringerStr(t any) (s string, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			s, ok = "", false
+		}
+	}()
+
+	switch v := t.(type) {
+	case error:
+		return v.Error(), true
+	case fmt.Stringer:
+		return v.String(), true
+	}
+
+	return "", false
+}
+
+// ObjsYAML will append a line to the logger with things rendered as
+// indented YAML, things that cannot be marshaled fall back to %#v.
+func ObjsYAML(things ...any) {
+	defDabugger.ObjsYAML(things...)
+}
+
+func (d *Dabugger) ObjsYAML(things ...any) {
+	if !d.enabled() {
+		return
+	}
+
+	var msgs []string
+	for i, t := range things {
+		body, err := yamlStr(d.redactCopy(t))
+		if err != nil {
+			body = fmt.Sprintf("%#v", t)
+		}
+		msgs = append(msgs, fmt.Sprintf("[%d]\n%s", i, indentLines(d.truncate(body), indent)))
+	}
+	d.appendMsg(strings.Join(msgs, "\n"))
+}
+
+// ObjsJSON will append a line to the logger with things rendered as
+// indented JSON, things that cannot be marshaled fall back to %#v.
+func ObjsJSON(things ...any) {
+	defDabugger.ObjsJSON(things...)
+}
+
+func (d *Dabugger) ObjsJSON(things ...any) {
+	if !d.enabled() {
+		return
+	}
+
+	var msgs []string
+	for i, t := range things {
+		var body string
+		b, err := json.MarshalIndent(d.redactCopy(t), "", indent)
+		if err != nil {
+			body = fmt.Sprintf("%#v", t)
+		} else {
+			body = string(b)
+		}
+		msgs = append(msgs, fmt.Sprintf("[%d]\n%s", i, indentLines(d.truncate(body), indent)))
+	}
+	d.appendMsg(strings.Join(msgs, "\n"))
+}
+
+// yamlStr marshals v as YAML, yaml.Marshal panics on unsupported types
+// such as channels and funcs so those are returned as errors.
+func yamlStr(v any) (s string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(b), "\n"), nil
+}
+
+// indentLines prefixes every line in s with prefix.
+func indentLines(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
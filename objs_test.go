@@ -0,0 +1,193 @@
+package dabug
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// msgFormatter renders only the message so tests can assert on it directly.
+type msgFormatter struct{}
+
+func (msgFormatter) Format(l *Line) string {
+	return l.Msg
+}
+
+func newMsgDabugger() (*Dabugger, *strings.Builder) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.SetFormatter(msgFormatter{})
+	return d, sb
+}
+
+func TestObjsYAML(t *testing.T) {
+	type address struct {
+		City string
+		Zip  string `yaml:"zip"`
+	}
+	type person struct {
+		Name    string
+		Address *address
+		Tags    map[string]int
+	}
+	p := person{"dave", &address{"earth", "12345"}, map[string]int{"b": 2, "a": 1}}
+
+	d, sb := newMsgDabugger()
+	d.ObjsYAML(p, make(chan int))
+
+	out := sb.String()
+	assert.Contains(t, out, "[0]\n"+
+		"  name: dave\n"+
+		"  address:\n"+
+		"      city: earth\n"+
+		"      zip: \"12345\"\n"+
+		"  tags:\n"+
+		"      a: 1\n"+
+		"      b: 2\n"+
+		"[1]\n"+
+		"  (chan int)")
+}
+
+func TestObjsJSON(t *testing.T) {
+	type person struct {
+		Name string `json:"name"`
+		Tags map[string]int
+	}
+
+	d, sb := newMsgDabugger()
+	d.ObjsJSON(&person{"dave", map[string]int{"b": 2, "a": 1}}, func() {})
+
+	out := sb.String()
+	assert.Contains(t, out, "[0]\n"+
+		"  {\n"+
+		"    \"name\": \"dave\",\n"+
+		"    \"Tags\": {\n"+
+		"      \"a\": 1,\n"+
+		"      \"b\": 2\n"+
+		"    }\n"+
+		"  }\n"+
+		"[1]\n"+
+		"  (func())")
+}
+
+type stringerPerson struct {
+	name string
+}
+
+func (p *stringerPerson) String() string {
+	return "person " + p.name
+}
+
+func TestStringers(t *testing.T) {
+	d, sb := newMsgDabugger()
+	d.Objs(&stringerPerson{"dave"}, errors.New("boom"))
+	assert.Contains(t, sb.String(), "stringerPerson")
+	assert.NotContains(t, sb.String(), "person dave")
+
+	sb.Reset()
+	d.Stringers(true)
+	var nilPerson *stringerPerson
+	d.Objs(&stringerPerson{"dave"}, errors.New("boom"), 12, nilPerson)
+	assert.Equal(t, "[0] person dave, [1] boom, [2] 12, [3] (*dabug.stringerPerson)(nil)\n", sb.String())
+}
+
+func TestMaxValueLen(t *testing.T) {
+	d, sb := newMsgDabugger()
+	d.MaxValueLen(8)
+	d.Objs(make([]byte, 100), "ok")
+	d.Msg("%s", strings.Repeat("é", 10))
+	d.Msg("fits")
+
+	parts := strings.Split(sb.String(), "\n")
+	assert.Equal(t, `[0] []byte{0… (506 bytes), [1] "ok"`, parts[0])
+	assert.Equal(t, "éééé… (20 bytes)", parts[1])
+	assert.Equal(t, "fits", parts[2])
+}
+
+func TestObjsSortedMaps(t *testing.T) {
+	type key struct {
+		a int
+		b string
+	}
+	type holder struct {
+		m map[string]int
+	}
+
+	m := map[string]int{}
+	for i := 0; i < 50; i++ {
+		m[string(rune('z'-i%26))+strings.Repeat("x", i/26)] = i
+	}
+	nested := holder{m}
+	structKeys := map[key]bool{{2, "a"}: true, {1, "b"}: false, {1, "a"}: true}
+
+	d, sb := newMsgDabugger()
+	d.Objs(m, nested, structKeys)
+	want := sb.String()
+	for i := 0; i < 10; i++ {
+		sb.Reset()
+		d.Objs(m, nested, structKeys)
+		require.Equal(t, want, sb.String())
+	}
+
+	assert.Contains(t, want, `map[string]int{"a":25, "b":24, "c":23, "cx":49, "d":22`)
+	assert.Contains(t, want, `map[dabug.key]bool{dabug.key{a:1, b:"a"}:true, dabug.key{a:1, b:"b"}:false, dabug.key{a:2, b:"a"}:true}`)
+
+	sb.Reset()
+	d.ObjsYAML(m)
+	yamlOut := sb.String()
+	assert.Less(t, strings.Index(yamlOut, "a: 25"), strings.Index(yamlOut, "b: 24"))
+}
+
+func TestObjsText(t *testing.T) {
+	type body struct {
+		Raw []byte
+	}
+
+	d, sb := newMsgDabugger()
+	d.Objs([]byte(`{"b":[1,2],"a":"x"}`), ` [1] `, "{not json", []byte("GET / HTTP/1.1\r\n"), []byte{0xff, 0}, body{[]byte("hi")}, "12")
+
+	assert.Equal(t, `[0] {
+  "b": [
+    1,
+    2
+  ],
+  "a": "x"
+}, [1] [
+  1
+], [2] "{not json", [3] []byte("GET / HTTP/1.1\r\n"), [4] []byte{0xff, 0x0}, [5] dabug.body{Raw:[]byte("hi")}, [6] "12"
+`, sb.String())
+}
+
+func TestObjsMarshalDisabled(t *testing.T) {
+	d, sb := newMsgDabugger()
+	marshaled := false
+	v := marshalSpy{&marshaled}
+
+	d.V(3).ObjsJSON(v)
+	d.V(3).ObjsYAML(v)
+	d.Mute()
+	d.ObjsJSON(v)
+	d.ObjsYAML(v)
+
+	assert.False(t, marshaled)
+	assert.Empty(t, sb.String())
+}
+
+// marshalSpy records whether it was marshaled as JSON or YAML.
+type marshalSpy struct {
+	marshaled *bool
+}
+
+func (m marshalSpy) MarshalJSON() ([]byte, error) {
+	*m.marshaled = true
+	return []byte("{}"), nil
+}
+
+func (m marshalSpy) MarshalYAML() (any, error) {
+	*m.marshaled = true
+	return map[string]any{}, nil
+}
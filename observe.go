@@ -0,0 +1,65 @@
+package dabug
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Observe records the duration v under name, observations are summarized
+// as count, min, max, mean, and p95 by Report and Flush instead of a line
+// per call.
+func Observe(name string, v time.Duration) {
+	defDabugger.Observe(name, v)
+}
+
+func (d *Dabugger) Observe(name string, v time.Duration) {
+	if !d.enabled() {
+		return
+	}
+
+	d.statsMutex.Lock()
+	defer d.statsMutex.Unlock()
+
+	if d.observations == nil {
+		d.observations = map[string][]time.Duration{}
+	}
+	d.observations[name] = append(d.observations[name], v)
+}
+
+func observationsStr(observations map[string][]time.Duration) string {
+	names := make([]string, 0, len(observations))
+	for name := range observations {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	sb := strings.Builder{}
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "observation\tcount\tmin\tmax\tmean\tp95")
+	for _, name := range names {
+		obs := slices.Clone(observations[name])
+		slices.Sort(obs)
+
+		var sum time.Duration
+		for _, o := range obs {
+			sum += o
+		}
+		mean := sum / time.Duration(len(obs))
+
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n", name, len(obs),
+			fmtDuration(obs[0]), fmtDuration(obs[len(obs)-1]),
+			fmtDuration(mean), fmtDuration(percentile(obs, 95)))
+	}
+	tw.Flush()
+
+	return "observations\n" + strings.TrimSuffix(sb.String(), "\n")
+}
+
+// percentile returns the nearest rank percentile p of the sorted obs.
+func percentile(obs []time.Duration, p int) time.Duration {
+	rank := (len(obs)*p + 99) / 100
+	return obs[max(rank, 1)-1]
+}
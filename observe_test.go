@@ -0,0 +1,51 @@
+package dabug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObserve(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	for i := 100; i >= 1; i-- {
+		d.Observe("req", time.Duration(i)*time.Millisecond)
+	}
+	d.Observe("db", 3*time.Second)
+	assert.Empty(t, rec.Lines())
+
+	d.Flush()
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Equal(t, "observations\n"+
+		"observation  count  min  max    mean    p95\n"+
+		"db           1      3s   3s     3s      3s\n"+
+		"req          100    1ms  100ms  50.5ms  95ms", lines[0].Msg)
+}
+
+func TestPercentile(t *testing.T) {
+	obs := []time.Duration{1, 2, 3, 4}
+	assert.Equal(t, time.Duration(4), percentile(obs, 95))
+	assert.Equal(t, time.Duration(2), percentile(obs, 50))
+	assert.Equal(t, time.Duration(1), percentile(obs, 0))
+}
+
+func TestObserveDisabled(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	d.V(3).Observe("req", time.Millisecond)
+	d.Mute()
+	d.Observe("req", time.Millisecond)
+	d.Unmute()
+
+	assert.Nil(t, d.observations)
+	d.Flush()
+	assert.Empty(t, rec.Lines())
+}
@@ -0,0 +1,143 @@
+package dabug
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// limiter decides which calls at a site are written.
+type limiter struct {
+	// allow reports whether the latest call at s is written
+	allow func(s *site) bool
+	// inline notes the suppressed calls on the next written line instead
+	// of at Flush
+	inline bool
+	// note is appended to every written line
+	note string
+}
+
+// site counts the calls at a single call site of a limited Dabugger.
+type site struct {
+	calls      int
+	suppressed int
+	// last is when a line was last written from the site
+	last time.Time
+}
+
+// Once returns a Dabugger that writes a line only the first time it is
+// called from a call site, later calls are counted and noted at Flush, ie:
+// `suppressed 99 more`.
+//
+//	for _, item := range items {
+//		dabug.Once().Msg("first item %v", item)
+//	}
+func Once() *Dabugger {
+	return defDabugger.Once()
+}
+
+func (d *Dabugger) Once() *Dabugger {
+	child := d.derive()
+	child.limit = &limiter{allow: func(s *site) bool { return s.calls == 1 }}
+	return child
+}
+
+// MsgOnce appends a message only the first time it is called from a call
+// site, see Once.
+func MsgOnce(format string, v ...any) {
+	defDabugger.MsgOnce(format, v...)
+}
+
+func (d *Dabugger) MsgOnce(format string, v ...any) {
+	d.Once().Msg(format, v...)
+}
+
+// EveryN returns a Dabugger that writes a line for the first and then every
+// nth call from a call site, the calls in between are noted at Flush as with
+// Once.
+func EveryN(n int) *Dabugger {
+	return defDabugger.EveryN(n)
+}
+
+func (d *Dabugger) EveryN(n int) *Dabugger {
+	if n <= 1 {
+		return d
+	}
+
+	child := d.derive()
+	child.limit = &limiter{allow: func(s *site) bool { return (s.calls-1)%n == 0 }}
+	return child
+}
+
+// NoteSuppressed controls if the lines suppressed by call site limits, ie:
+// Once or Throttle, since the last Flush are noted as a line per call site
+// at Flush, enabled by default.
+func NoteSuppressed(on bool) {
+	defDabugger.NoteSuppressed(on)
+}
+
+func (d *Dabugger) NoteSuppressed(on bool) {
+	d.statsMutex.Lock()
+	defer d.statsMutex.Unlock()
+
+	d.noteSuppressed = on
+}
+
+// allowSite counts a call at src and reports whether the limit of d allows
+// writing it, and the note to append to the line.
+func (d *Dabugger) allowSite(src Source) (bool, string) {
+	if d.limit == nil {
+		return true, ""
+	}
+
+	d.statsMutex.Lock()
+	defer d.statsMutex.Unlock()
+
+	if d.sites == nil {
+		d.sites = map[Source]*site{}
+	}
+	s, ok := d.sites[src]
+	if !ok {
+		s = &site{}
+		d.sites[src] = s
+	}
+
+	s.calls++
+	if !d.limit.allow(s) {
+		s.suppressed++
+		return false, ""
+	}
+
+	s.last = time.Now()
+	note := d.limit.note
+	if d.limit.inline && s.suppressed > 0 {
+		note += fmt.Sprintf(" (suppressed %d)", s.suppressed)
+		s.suppressed = 0
+	}
+	return true, note
+}
+
+// writeSuppressed appends a line for every call site with suppressed lines
+// and resets the suppressed counts.
+func (d *Dabugger) writeSuppressed() {
+	d.statsMutex.Lock()
+	var notes []*Line
+	for src, s := range d.sites {
+		if s.suppressed > 0 && d.noteSuppressed {
+			notes = append(notes, &Line{Source: src, Msg: fmt.Sprintf("suppressed %d more", s.suppressed)})
+		}
+		s.suppressed = 0
+	}
+	d.statsMutex.Unlock()
+
+	slices.SortFunc(notes, func(a, b *Line) int {
+		if c := cmp.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Line, b.Line)
+	})
+	for _, l := range notes {
+		d.appendLine(l)
+	}
+}
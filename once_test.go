@@ -0,0 +1,62 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOnce(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	for i := 0; i < 5; i++ {
+		d.Once().Msg("once %d", i)
+		d.MsgOnce("msg once %d", i)
+	}
+	d.Flush()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 4)
+	assert.Equal(t, "once 0", lines[0].Msg)
+	assert.Equal(t, "msg once 0", lines[1].Msg)
+	assert.Equal(t, "suppressed 4 more", lines[2].Msg)
+	assert.Equal(t, lines[0].Source, lines[2].Source)
+	assert.Equal(t, "suppressed 4 more", lines[3].Msg)
+	assert.Equal(t, lines[1].Source, lines[3].Source)
+
+	// sites are remembered across flushes
+	rec.Reset()
+	for i := 0; i < 2; i++ {
+		d.Once().Msg("once")
+	}
+	d.Flush()
+	d.Flush()
+	lines = rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "once", lines[0].Msg)
+	assert.Equal(t, "suppressed 1 more", lines[1].Msg)
+}
+
+func TestEveryN(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.NoteSuppressed(false)
+
+	for i := 0; i < 7; i++ {
+		d.EveryN(3).Msg("%d", i)
+	}
+	d.EveryN(1).Msg("always")
+	d.EveryN(1).Msg("always")
+	d.Flush()
+
+	var msgs []string
+	for _, l := range rec.Lines() {
+		msgs = append(msgs, l.Msg)
+	}
+	assert.Equal(t, []string{"0", "3", "6", "always", "always"}, msgs)
+	assert.Equal(t, 7, d.sites[rec.Lines()[0].Source].calls)
+}
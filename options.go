@@ -0,0 +1,43 @@
+package dabug
+
+import (
+	"io"
+	"os"
+)
+
+// Option configures a Dabugger, see New and Configure.
+type Option func(d *Dabugger)
+
+// StdErr writes output to stderr instead of stdout, for programs that emit
+// machine-readable data on stdout, ie:
+//
+//	d := dabug.New(dabug.StdErr())
+func StdErr() Option {
+	return WithWriter(os.Stderr)
+}
+
+// StdOut writes output to stdout, the default.
+func StdOut() Option {
+	return WithWriter(os.Stdout)
+}
+
+// WithWriter replaces the writers output is written to with w.
+func WithWriter(w io.Writer) Option {
+	return func(d *Dabugger) {
+		d.Writer(w)
+	}
+}
+
+// Configure applies opts to the default Dabugger, ie to send all output to
+// stderr:
+//
+//	dabug.Configure(dabug.StdErr())
+func Configure(opts ...Option) {
+	defDabugger.Configure(opts...)
+}
+
+func (d *Dabugger) Configure(opts ...Option) {
+	for _, opt := range opts {
+		opt(d)
+	}
+}
@@ -0,0 +1,34 @@
+package dabug
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdErr(t *testing.T) {
+	d := New(StdErr())
+	require.Len(t, d.writers, 1)
+	assert.Equal(t, os.Stderr, d.writers[0])
+
+	d.Configure(StdOut())
+	assert.Equal(t, os.Stdout, d.writers[0])
+}
+
+func TestConfigure(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New(WithWriter(sb))
+	d.LinePrefix("")
+
+	d.Msg("hello")
+	assert.Contains(t, sb.String(), "hello")
+
+	orig := defDabugger.writers
+	defer func() { defDabugger.writers = orig }()
+
+	Configure(StdErr())
+	assert.Equal(t, os.Stderr, defDabugger.writers[0])
+}
@@ -0,0 +1,62 @@
+package dabug
+
+import "reflect"
+
+// maxPointerIDs bounds the number of pointers PointerIDs remembers.
+const maxPointerIDs = 1 << 16
+
+// PointerIDs annotates pointers in Objs output with short ids, ie:
+// `p#3 &main.Node{...}`, that stay the same for the same pointer across
+// lines, showing whether two lines refer to the same object or to copies.
+// Disabling forgets the assigned ids.
+//
+// Ids are assigned by address and the objects are not tracked, so an object
+// allocated at the address of one that was garbage collected gets the id of
+// the dead object. Only the last 65536 pointers are remembered, after that
+// the ids are forgotten and pointers get new ids, never reused ones.
+func PointerIDs(enabled bool) {
+	defDabugger.PointerIDs(enabled)
+}
+
+func (d *Dabugger) PointerIDs(enabled bool) {
+	d.statsMutex.Lock()
+	defer d.statsMutex.Unlock()
+
+	d.pointerIDs, d.lastPointerID = nil, 0
+	if enabled {
+		d.pointerIDs = map[visit]int{}
+	}
+}
+
+// pointerIDFunc returns the func assigning pointer ids, or nil if they are
+// disabled.
+func (d *Dabugger) pointerIDFunc() func(v reflect.Value) int {
+	d.statsMutex.Lock()
+	enabled := d.pointerIDs != nil
+	d.statsMutex.Unlock()
+
+	if !enabled {
+		return nil
+	}
+
+	return func(v reflect.Value) int {
+		d.statsMutex.Lock()
+		defer d.statsMutex.Unlock()
+
+		if d.pointerIDs == nil {
+			return 0
+		}
+
+		key := visit{v.Pointer(), v.Type()}
+		id, ok := d.pointerIDs[key]
+		if !ok {
+			if len(d.pointerIDs) >= maxPointerIDs {
+				clear(d.pointerIDs)
+			}
+			d.lastPointerID++
+			id = d.lastPointerID
+			d.pointerIDs[key] = id
+		}
+		return id
+	}
+}
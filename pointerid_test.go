@@ -0,0 +1,41 @@
+package dabug
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointerIDs(t *testing.T) {
+	a := &prettyNode{Name: "a"}
+	b := &prettyNode{Name: "a"}
+	n := 1
+
+	d, sb := newMsgDabugger()
+	d.Objs(a)
+	d.PointerIDs(true)
+	d.Objs(a, b)
+	d.Objs(&prettyNode{Name: "c", Next: a}, &n)
+	d.PointerIDs(false)
+	d.Objs(a)
+
+	assert.Equal(t, `[0] &dabug.prettyNode{Name:"a", Next:(*dabug.prettyNode)(nil)}
+[0] p#1 &dabug.prettyNode{Name:"a", Next:(*dabug.prettyNode)(nil)}, [1] p#2 &dabug.prettyNode{Name:"a", Next:(*dabug.prettyNode)(nil)}
+[0] p#3 &dabug.prettyNode{Name:"c", Next:p#1 &dabug.prettyNode{Name:"a", Next:(*dabug.prettyNode)(nil)}}, [1] p#4 &1
+[0] &dabug.prettyNode{Name:"a", Next:(*dabug.prettyNode)(nil)}
+`, sb.String())
+}
+
+func TestPointerIDsBounded(t *testing.T) {
+	d := New()
+	d.PointerIDs(true)
+	id := d.pointerIDFunc()
+
+	nodes := make([]prettyNode, maxPointerIDs+1)
+	for i := range nodes {
+		assert.Equal(t, i+1, id(reflect.ValueOf(&nodes[i])))
+	}
+	assert.Len(t, d.pointerIDs, 1)
+	assert.Equal(t, maxPointerIDs+2, id(reflect.ValueOf(&nodes[0])), "forgotten pointers get new ids")
+}
@@ -0,0 +1,36 @@
+package dabug
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// WithPprofLabels adds the key/value pairs kv to the runtime/pprof labels
+// of ctx and sets them as the labels of the calling goroutine, and adds kv
+// as context pairs to the lines of the calling goroutine and the goroutines
+// it starts with Go, so profiles and dabug output can be correlated by the
+// same keys. Other goroutines are not affected. The returned func removes
+// the pairs and restores the labels of ctx, ie:
+//
+//	ctx, done := dabug.WithPprofLabels(ctx, "request", id)
+//	defer done()
+func WithPprofLabels(ctx context.Context, kv ...string) (context.Context, func()) {
+	return defDabugger.WithPprofLabels(ctx, kv...)
+}
+
+func (d *Dabugger) WithPprofLabels(ctx context.Context, kv ...string) (context.Context, func()) {
+	// validates kv before changing anything
+	labeled := pprof.WithLabels(ctx, pprof.Labels(kv...))
+	pprof.SetGoroutineLabels(labeled)
+
+	var fields Fields
+	for i := 0; i+1 < len(kv); i += 2 {
+		fields = append(fields, Field{kv[i], kv[i+1]})
+	}
+	pop := pushContexts(fields)
+
+	return labeled, func() {
+		pop()
+		pprof.SetGoroutineLabels(ctx)
+	}
+}
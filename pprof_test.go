@@ -0,0 +1,80 @@
+package dabug
+
+import (
+	"context"
+	"runtime/pprof"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pprofLabels(ctx context.Context) map[string]string {
+	labels := map[string]string{}
+	pprof.ForLabels(ctx, func(k, v string) bool {
+		labels[k] = v
+		return true
+	})
+	return labels
+}
+
+func TestWithPprofLabels(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.AddContext("service", "api")
+
+	parent := pprof.WithLabels(context.Background(), pprof.Labels("tenant", "x"))
+	ctx, done := d.WithPprofLabels(parent, "request", "7")
+	d.Msg("inside")
+	assert.Equal(t, map[string]string{"tenant": "x", "request": "7"}, pprofLabels(ctx))
+
+	done()
+	d.Msg("after")
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, Fields{{"service", "api"}, {"request", "7"}}, lines[0].Context)
+	assert.Equal(t, Fields{{"service", "api"}}, lines[1].Context)
+
+	assert.Panics(t, func() { d.WithPprofLabels(context.Background(), "odd") })
+}
+
+func TestWithPprofLabelsConcurrent(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	labels := make([]map[string]string, 2)
+	var inside, wg sync.WaitGroup
+	inside.Add(2)
+	wg.Add(2)
+	for i, kv := range [][]string{{"request", "A", "user", "alice"}, {"request", "B"}} {
+		go func(i int, kv []string) {
+			defer wg.Done()
+			ctx, done := d.WithPprofLabels(context.Background(), kv...)
+			defer done()
+
+			// both goroutines have their labels set at the same time
+			inside.Done()
+			inside.Wait()
+			labels[i] = pprofLabels(ctx)
+			d.Msg("%s", kv[1])
+		}(i, kv)
+	}
+	wg.Wait()
+
+	assert.Equal(t, map[string]string{"request": "A", "user": "alice"}, labels[0])
+	assert.Equal(t, map[string]string{"request": "B"}, labels[1])
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	for _, l := range lines {
+		if l.Msg == "A" {
+			assert.Equal(t, Fields{{"request", "A"}, {"user", "alice"}}, l.Context)
+		} else {
+			assert.Equal(t, Fields{{"request", "B"}}, l.Context)
+		}
+	}
+}
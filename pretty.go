@@ -0,0 +1,438 @@
+package dabug
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// defMaxDepth is the default depth beyond which Objs collapses values.
+	defMaxDepth = 10
+	// defMaxElems is the default number of elements Objs renders per
+	// collection.
+	defMaxElems = 100
+)
+
+// MaxDepth collapses values nested deeper than n levels in Objs output to
+// `T{…}`, set n to 0 to disable the limit, the default is 10.
+func MaxDepth(n int) {
+	defDabugger.MaxDepth(n)
+}
+
+func (d *Dabugger) MaxDepth(n int) {
+	d.maxDepth = max(n, 0)
+}
+
+// MaxElems limits the number of elements of each slice, array, and map
+// rendered by Objs, the rest are noted as `…(N more)`. Set n to 0 to
+// disable the limit, the default is 100.
+func MaxElems(n int) {
+	defDabugger.MaxElems(n)
+}
+
+func (d *Dabugger) MaxElems(n int) {
+	d.maxElems = max(n, 0)
+}
+
+// TimeFormat renders time.Time values in Objs formatted with layout in loc
+// instead of their internal fields, ie:
+//
+//	dabug.TimeFormat(time.RFC3339, time.UTC)
+//
+// A nil loc keeps the location of each time, an empty layout restores the
+// default rendering.
+func TimeFormat(layout string, loc *time.Location) {
+	defDabugger.TimeFormat(layout, loc)
+}
+
+func (d *Dabugger) TimeFormat(layout string, loc *time.Location) {
+	d.timeLayout = layout
+	d.timeLoc = loc
+}
+
+// Pretty renders Objs values indented across multiple lines instead of on a
+// single line.
+func Pretty(enabled bool) {
+	defDabugger.Pretty(enabled)
+}
+
+func (d *Dabugger) Pretty(enabled bool) {
+	d.pretty = enabled
+}
+
+// printer renders values in Go syntax similar to %#v, but dereferencing
+// pointers, sorting map keys, and detecting cycles.
+type printer struct {
+	sb        strings.Builder
+	maxDepth  int
+	maxElems  int
+	multiline bool
+	redact    map[string]bool
+	redactor  Redactor
+	// timeLayout and timeLoc render time.Time values when timeLayout is set
+	timeLayout string
+	timeLoc    *time.Location
+	// pointerID returns the identity of a pointer, nil disables ids
+	pointerID func(v reflect.Value) int
+	// stable omits addresses so that output is the same between runs
+	stable bool
+	// visiting contains the pointers and maps on the path to the current
+	// value, used to detect cycles
+	visiting map[visit]bool
+}
+
+// visit identifies a pointer or map by address and type, the type is needed
+// as a struct and its first field share an address.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+func (d *Dabugger) newPrinter() *printer {
+	return &printer{
+		maxDepth:   d.maxDepth,
+		maxElems:   d.maxElems,
+		multiline:  d.pretty,
+		redact:     d.redact,
+		redactor:   d.redactor,
+		timeLayout: d.timeLayout,
+		timeLoc:    d.timeLoc,
+		pointerID:  d.pointerIDFunc(),
+		visiting:   map[visit]bool{},
+	}
+}
+
+// prettyStr renders t with the printer settings of d.
+func (d *Dabugger) prettyStr(t any) string {
+	return d.prettyValue(reflect.ValueOf(t))
+}
+
+func (d *Dabugger) prettyValue(v reflect.Value) string {
+	p := d.newPrinter()
+	p.value(v, 0)
+	return p.sb.String()
+}
+
+func (p *printer) value(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		p.sb.WriteString("<nil>")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		p.sb.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		p.sb.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		p.sb.WriteString("0x" + strconv.FormatUint(v.Uint(), 16))
+	case reflect.Float32, reflect.Float64:
+		p.sb.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		p.sb.WriteString(strconv.FormatComplex(v.Complex(), 'g', -1, v.Type().Bits()))
+	case reflect.String:
+		p.sb.WriteString(strconv.Quote(v.String()))
+	case reflect.Interface:
+		if v.IsNil() {
+			fmt.Fprintf(&p.sb, "%s(nil)", v.Type())
+			return
+		}
+		if isNil(v.Elem()) {
+			// a typed nil, the interface itself is not nil
+			fmt.Fprintf(&p.sb, "%s(", v.Type())
+			p.value(v.Elem(), depth)
+			p.sb.WriteString(")")
+			return
+		}
+		p.value(v.Elem(), depth)
+	case reflect.Pointer:
+		p.pointer(v, depth)
+	case reflect.Struct:
+		if p.timeLayout != "" && v.Type() == reflect.TypeOf(time.Time{}) && v.CanInterface() {
+			t := v.Interface().(time.Time)
+			if p.timeLoc != nil {
+				t = t.In(p.timeLoc)
+			}
+			p.sb.WriteString(t.Format(p.timeLayout))
+			return
+		}
+		p.items(v.Type().String(), v.NumField(), v.NumField(), depth, func(i int) {
+			f := v.Type().Field(i)
+			p.sb.WriteString(f.Name + p.colon())
+			if f.Tag.Get("dabug") == "redact" || p.redacted(f.Name) {
+				p.sb.WriteString(redacted)
+				return
+			}
+			p.value(p.redactValue(f.Name, v.Field(i)), depth+1)
+		})
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			fmt.Fprintf(&p.sb, "%s(nil)", v.Type())
+			return
+		}
+		if v.Type() == reflect.TypeOf([]byte(nil)) && isText(v.Bytes()) {
+			p.sb.WriteString("[]byte(" + strconv.Quote(string(v.Bytes())) + ")")
+			return
+		}
+		p.items(typeStr(v.Type()), v.Len(), p.limit(v.Len()), depth, func(i int) {
+			p.value(v.Index(i), depth+1)
+		})
+	case reflect.Map:
+		p.mapValue(v, depth)
+	default:
+		// chan, func, and unsafe pointers
+		switch {
+		case v.IsNil():
+			fmt.Fprintf(&p.sb, "(%s)(nil)", v.Type())
+		case p.stable:
+			fmt.Fprintf(&p.sb, "(%s)(non-nil)", v.Type())
+		default:
+			fmt.Fprintf(&p.sb, "(%s)(%#x)", v.Type(), v.Pointer())
+		}
+	}
+}
+
+func (p *printer) pointer(v reflect.Value, depth int) {
+	if v.IsNil() {
+		fmt.Fprintf(&p.sb, "(%s)(nil)", v.Type())
+		return
+	}
+	if s, ok := protoStr(v); ok {
+		fmt.Fprintf(&p.sb, "%s{%s}", v.Type(), s)
+		return
+	}
+
+	if !p.enter(v) {
+		if p.stable {
+			fmt.Fprintf(&p.sb, "<cycle %s>", v.Type())
+		} else {
+			fmt.Fprintf(&p.sb, "<cycle (%s)(%#x)>", v.Type(), v.Pointer())
+		}
+		return
+	}
+	defer p.leave(v)
+
+	if p.pointerID != nil {
+		fmt.Fprintf(&p.sb, "p#%d ", p.pointerID(v))
+	}
+	p.sb.WriteString("&")
+	p.value(v.Elem(), depth)
+}
+
+func (p *printer) mapValue(v reflect.Value, depth int) {
+	if v.IsNil() {
+		fmt.Fprintf(&p.sb, "%s(nil)", v.Type())
+		return
+	}
+
+	if !p.enter(v) {
+		fmt.Fprintf(&p.sb, "<cycle %s>", v.Type())
+		return
+	}
+	defer p.leave(v)
+
+	keys := v.MapKeys()
+	slices.SortFunc(keys, compareValues)
+	p.items(v.Type().String(), len(keys), p.limit(len(keys)), depth, func(i int) {
+		p.value(keys[i], depth+1)
+		p.sb.WriteString(p.colon())
+		if keys[i].Kind() == reflect.String && p.redacted(keys[i].String()) {
+			p.sb.WriteString(redacted)
+			return
+		}
+		val := v.MapIndex(keys[i])
+		if keys[i].Kind() == reflect.String {
+			val = p.redactValue(keys[i].String(), val)
+		}
+		p.value(val, depth+1)
+	})
+}
+
+// enter marks the pointer or map v as being rendered, returning false if it
+// already is which means v refers to itself.
+func (p *printer) enter(v reflect.Value) bool {
+	key := visit{v.Pointer(), v.Type()}
+	if p.visiting[key] {
+		return false
+	}
+	p.visiting[key] = true
+	return true
+}
+
+func (p *printer) leave(v reflect.Value) {
+	delete(p.visiting, visit{v.Pointer(), v.Type()})
+}
+
+// items renders a composite value of n elements as `typ{...}`, calling item
+// for each of the first shown elements.
+func (p *printer) items(typ string, n, shown, depth int, item func(i int)) {
+	p.sb.WriteString(typ + "{")
+	if n == 0 {
+		p.sb.WriteString("}")
+		return
+	}
+	if p.maxDepth > 0 && depth >= p.maxDepth {
+		p.sb.WriteString("…}")
+		return
+	}
+
+	sep := func(i int) {
+		if p.multiline {
+			p.sb.WriteString("\n" + strings.Repeat(indent, depth+1))
+		} else if i > 0 {
+			p.sb.WriteString(", ")
+		}
+	}
+	end := func() {
+		if p.multiline {
+			p.sb.WriteString(",")
+		}
+	}
+
+	for i := 0; i < shown; i++ {
+		sep(i)
+		item(i)
+		end()
+	}
+	if shown < n {
+		sep(shown)
+		fmt.Fprintf(&p.sb, "…(%d more)", n-shown)
+		end()
+	}
+
+	if p.multiline {
+		p.sb.WriteString("\n" + strings.Repeat(indent, depth))
+	}
+	p.sb.WriteString("}")
+}
+
+// limit returns how many of n elements are shown.
+func (p *printer) limit(n int) int {
+	if p.maxElems > 0 {
+		return min(n, p.maxElems)
+	}
+	return n
+}
+
+// colon separates keys and values.
+func (p *printer) colon() string {
+	if p.multiline {
+		return ": "
+	}
+	return ":"
+}
+
+// protoStr renders protobuf messages in the text format using their String
+// method, which generated messages implement with prototext, instead of
+// exposing their internal state fields. Messages are detected by their
+// ProtoReflect method so there is no dependency on the protobuf module.
+func protoStr(v reflect.Value) (string, bool) {
+	m, ok := v.Type().MethodByName("ProtoReflect")
+	if !ok || m.Type.NumIn() != 1 || m.Type.NumOut() != 1 || !v.CanInterface() {
+		return "", false
+	}
+	s, ok := v.Interface().(fmt.Stringer)
+	if !ok {
+		return "", false
+	}
+	return stringerStr(s)
+}
+
+// isText reports whether b is non empty printable UTF-8 text.
+func isText(b []byte) bool {
+	if len(b) == 0 || !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isNil reports whether v is a nil pointer, map, chan, func, or slice.
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.Slice, reflect.UnsafePointer, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// typeStr returns the name of t as written in Go source.
+func typeStr(t reflect.Type) string {
+	if t == reflect.TypeOf([]byte(nil)) {
+		return "[]byte"
+	}
+	return t.String()
+}
+
+// compareValues orders map keys the same way fmt does.
+func compareValues(a, b reflect.Value) int {
+	if a.Kind() != b.Kind() {
+		return cmp.Compare(a.Kind(), b.Kind())
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		if c := cmp.Compare(real(a.Complex()), real(b.Complex())); c != 0 {
+			return c
+		}
+		return cmp.Compare(imag(a.Complex()), imag(b.Complex()))
+	case reflect.Bool:
+		switch {
+		case a.Bool() == b.Bool():
+			return 0
+		case a.Bool():
+			return 1
+		}
+		return -1
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		return cmp.Compare(a.Pointer(), b.Pointer())
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if c := compareValues(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if c := compareValues(a.Index(i), b.Index(i)); c != 0 {
+				return c
+			}
+		}
+	case reflect.Interface:
+		switch {
+		case a.IsNil() || b.IsNil():
+			return cmp.Compare(btoi(!a.IsNil()), btoi(!b.IsNil()))
+		case a.Elem().Type() != b.Elem().Type():
+			return cmp.Compare(a.Elem().Type().String(), b.Elem().Type().String())
+		}
+		return compareValues(a.Elem(), b.Elem())
+	}
+
+	return 0
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,114 @@
+package dabug
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type prettyNode struct {
+	Name string
+	Next *prettyNode
+}
+
+func TestPrettyMatchesGoSyntax(t *testing.T) {
+	type inner struct {
+		n int
+		f float64
+		u uint8
+		s []string
+		e error
+		a any
+	}
+	var nilMap map[string]int
+	values := []any{
+		nil, 12, -3.5, uint(255), "quote\"d", true, 2 + 3i,
+		[]int{1, 2, 3}, [2]bool{true, false}, []string(nil), nilMap,
+		map[int]string{3: "c", 1: "a", 2: "b"},
+		inner{n: 1, f: 1e21, u: 7, s: []string{"x"}, a: "any"},
+		struct{ x, y int }{1, 2},
+		(*inner)(nil),
+	}
+
+	d := New()
+	for _, v := range values {
+		assert.Equal(t, fmt.Sprintf("%#v", v), d.prettyStr(v))
+	}
+}
+
+func TestPrettyPointers(t *testing.T) {
+	d := New()
+	n := &prettyNode{"a", &prettyNode{"b", nil}}
+	assert.Equal(t, `&dabug.prettyNode{Name:"a", Next:&dabug.prettyNode{Name:"b", Next:(*dabug.prettyNode)(nil)}}`, d.prettyStr(n))
+
+	n.Next.Next = n
+	assert.Regexp(t, `^&dabug.prettyNode\{Name:"a", Next:&dabug.prettyNode\{Name:"b", Next:<cycle \(\*dabug.prettyNode\)\(0x[0-9a-f]+\)>\}\}$`, d.prettyStr(n))
+
+	m := map[string]any{}
+	m["self"] = m
+	assert.Equal(t, `map[string]interface {}{"self":<cycle map[string]interface {}>}`, d.prettyStr(m))
+
+	// repeated but acyclic pointers are rendered each time
+	shared := &prettyNode{Name: "s"}
+	assert.Equal(t, 2, strings.Count(d.prettyStr([]*prettyNode{shared, shared}), `"s"`))
+}
+
+func TestMaxDepth(t *testing.T) {
+	d := New()
+	d.MaxDepth(2)
+	n := &prettyNode{"a", &prettyNode{"b", &prettyNode{"c", nil}}}
+	assert.Equal(t, `&dabug.prettyNode{Name:"a", Next:&dabug.prettyNode{Name:"b", Next:&dabug.prettyNode{…}}}`, d.prettyStr(n))
+
+	d.MaxDepth(0)
+	assert.Contains(t, d.prettyStr(n), `"c"`)
+}
+
+func TestMaxElems(t *testing.T) {
+	d := New()
+	d.MaxElems(3)
+	assert.Equal(t, "[]int{0, 1, 2, …(7 more)}", d.prettyStr([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}))
+	assert.Equal(t, `map[string]int{"a":1, "b":2, "c":3, …(1 more)}`, d.prettyStr(map[string]int{"d": 4, "c": 3, "b": 2, "a": 1}))
+	assert.Equal(t, "[]int{1, 2}", d.prettyStr([]int{1, 2}))
+}
+
+func TestPretty(t *testing.T) {
+	d, sb := newMsgDabugger()
+	d.Pretty(true)
+	d.Objs(map[string][]int{"a": {1, 2}, "b": {}}, prettyNode{Name: "n"})
+
+	assert.Equal(t, `[0] map[string][]int{
+  "a": []int{
+    1,
+    2,
+  },
+  "b": []int{},
+}, [1] dabug.prettyNode{
+  Name: "n",
+  Next: (*dabug.prettyNode)(nil),
+}
+`, sb.String())
+}
+
+func TestTimeFormat(t *testing.T) {
+	type event struct {
+		At   time.Time
+		Prev *time.Time
+	}
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("X", 3600))
+	e := event{at, &at}
+
+	d := New()
+	assert.Contains(t, d.prettyStr(e), "wall:")
+
+	d.TimeFormat(time.RFC3339, time.UTC)
+	assert.Equal(t, "dabug.event{At:2024-01-02T02:04:05Z, Prev:&2024-01-02T02:04:05Z}", d.prettyStr(e))
+
+	d.TimeFormat(time.RFC3339, nil)
+	assert.Equal(t, "2024-01-02T03:04:05+01:00", d.prettyStr(at))
+
+	d.TimeFormat("", nil)
+	assert.Contains(t, d.prettyStr(at), "wall:")
+}
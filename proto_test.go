@@ -0,0 +1,36 @@
+package dabug
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProto mimics a generated protobuf message.
+type fakeProto struct {
+	state     struct{ cache []byte }
+	sizeCache int32
+	Name      string
+	Id        int64
+}
+
+func (m *fakeProto) ProtoReflect() fmt.Stringer { return nil }
+
+func (m *fakeProto) String() string {
+	return fmt.Sprintf("name:%q id:%d", m.Name, m.Id)
+}
+
+func TestObjsProto(t *testing.T) {
+	type wrapper struct {
+		Msg *fakeProto
+		Nil *fakeProto
+	}
+
+	d, sb := newMsgDabugger()
+	d.Objs(&fakeProto{Name: "dave", Id: 3}, wrapper{Msg: &fakeProto{Name: "x"}})
+
+	assert.Equal(t, `[0] *dabug.fakeProto{name:"dave" id:3}, `+
+		`[1] dabug.wrapper{Msg:*dabug.fakeProto{name:"x" id:0}, Nil:(*dabug.fakeProto)(nil)}`+"\n", sb.String())
+	assert.NotContains(t, sb.String(), "sizeCache")
+}
@@ -0,0 +1,72 @@
+package dabug
+
+import (
+	"strings"
+	"sync"
+)
+
+// Recorder is a LineWriter that keeps every line in memory so that tests
+// and tools can inspect output without parsing it.
+type Recorder struct {
+	mu    sync.Mutex
+	lines []Line
+}
+
+// NewRecorder creates an empty Recorder, use it with Writer or AddWriter.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) WriteLine(l Line) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lines = append(r.lines, l)
+	return nil
+}
+
+// Write records each line of text written directly to the Recorder as a
+// Line with only Msg set.
+func (r *Recorder) Write(p []byte) (int, error) {
+	for _, l := range textLines(p) {
+		r.WriteLine(l)
+	}
+	return len(p), nil
+}
+
+// Lines returns a copy of all recorded lines.
+func (r *Recorder) Lines() []Line {
+	return r.Filter(func(Line) bool { return true })
+}
+
+// Filter returns the recorded lines for which pred returns true.
+func (r *Recorder) Filter(pred func(l Line) bool) []Line {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var lines []Line
+	for _, l := range r.lines {
+		if pred(l) {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+// Reset discards all recorded lines.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lines = nil
+}
+
+// textLines splits text written to a LineWriter into lines with only Msg
+// set.
+func textLines(p []byte) []Line {
+	var lines []Line
+	for _, msg := range strings.Split(strings.TrimSuffix(string(p), "\n"), "\n") {
+		lines = append(lines, Line{Msg: msg})
+	}
+	return lines
+}
@@ -0,0 +1,44 @@
+package dabug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorder(t *testing.T) {
+	rec := NewRecorder()
+	sb := &strings.Builder{}
+
+	d := New()
+	d.Writer(sb)
+	d.AddWriter(rec)
+	d.AddContext("k", "v")
+	d.Msg("one")
+	d.AutoFlush(false)
+	d.Warn("two")
+	d.Msg("three")
+	d.Flush()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 3)
+	assert.Equal(t, "one", lines[0].Msg)
+	assert.Equal(t, "recorder_test.go", lines[0].File)
+	assert.Equal(t, Fields{{"k", "v"}}, lines[0].Context)
+	assert.Equal(t, LevelWarn, lines[1].Level)
+
+	warns := rec.Filter(func(l Line) bool { return l.Level == LevelWarn })
+	require.Len(t, warns, 1)
+	assert.Equal(t, "two", warns[0].Msg)
+
+	// text writers are unaffected
+	assert.Contains(t, sb.String(), sectionBeg)
+
+	rec.Reset()
+	assert.Empty(t, rec.Lines())
+
+	rec.Write([]byte("a\nb\n"))
+	assert.Equal(t, []Line{{Msg: "a"}, {Msg: "b"}}, rec.Lines())
+}
@@ -0,0 +1,261 @@
+package dabug
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// redacted replaces the values of redacted fields.
+const redacted = "***"
+
+// Redact renders the struct fields and string map keys with any of the given
+// names as `***` in the output of Objs, ObjsJSON, ObjsYAML, Table, Snapshot,
+// and the other value dumps, names are matched ignoring case. Redacted
+// values that cannot hold `***` are written as their zero value by the
+// dumps that keep the type, ie: ObjsJSON. Struct fields can also be redacted
+// with a tag, ie:
+//
+//	Password string `dabug:"redact"`
+func Redact(fields ...string) {
+	defDabugger.Redact(fields...)
+}
+
+func (d *Dabugger) Redact(fields ...string) {
+	if d.redact == nil {
+		d.redact = map[string]bool{}
+	}
+	for _, f := range fields {
+		d.redact[strings.ToLower(f)] = true
+	}
+}
+
+// redacted reports whether values named name are redacted.
+func (p *printer) redacted(name string) bool {
+	return len(p.redact) > 0 && p.redact[strings.ToLower(name)]
+}
+
+func (d *Dabugger) redacted(name string) bool {
+	return len(d.redact) > 0 && d.redact[strings.ToLower(name)]
+}
+
+// Redactor is called with the key and value of every context pair, struct
+// field, and string keyed map entry rendered by the value dumps, ie: Objs,
+// ObjsJSON, Var, or Table. It returns the value to write instead and true if
+// v must be replaced.
+// Values passed to Objs directly have an empty key, and unexported struct
+// fields are passed with a nil value as they cannot be read.
+type Redactor func(key string, v any) (any, bool)
+
+// SetRedactor scrubs values with r before they are written, set r to nil to
+// disable it.
+func SetRedactor(r Redactor) {
+	defDabugger.SetRedactor(r)
+}
+
+func (d *Dabugger) SetRedactor(r Redactor) {
+	d.redactor = r
+}
+
+// redactValue returns the replacement for v if the redactor has one.
+func (d *Dabugger) redactValue(key string, v any) any {
+	if d.redactor != nil {
+		if r, ok := d.redactor(key, v); ok {
+			return r
+		}
+	}
+	return v
+}
+
+// redactContext scrubs the values of ctx in place.
+func (d *Dabugger) redactContext(ctx Fields) Fields {
+	if d.redactor == nil {
+		return ctx
+	}
+	for i, f := range ctx {
+		if r, ok := d.redactor(f.Key, f.Value); ok {
+			ctx[i].Value = fmt.Sprint(r)
+		}
+	}
+	return ctx
+}
+
+func (p *printer) redactValue(key string, v reflect.Value) reflect.Value {
+	return redactReflect(p.redactor, key, v)
+}
+
+// redactField returns the Redactor replacement for the struct field or map
+// value v named key, or v.
+func (d *Dabugger) redactField(key string, v reflect.Value) reflect.Value {
+	return redactReflect(d.redactor, key, v)
+}
+
+// redactReflect returns the replacement of r for v, values that cannot be
+// read are passed as nil.
+func redactReflect(r Redactor, key string, v reflect.Value) reflect.Value {
+	if r == nil || !v.IsValid() {
+		return v
+	}
+
+	var val any
+	if v.CanInterface() {
+		val = v.Interface()
+	}
+	if rv, ok := r(key, val); ok {
+		return reflect.ValueOf(rv)
+	}
+	return v
+}
+
+// redactCopy returns a copy of v with the values Objs renders as `***`
+// replaced, for the output that is not rendered by the printer, ie:
+// ObjsJSON, ObjsYAML, Table, and Snapshot. Redacted strings and interfaces
+// are set to `***` and other types to their zero value, the Redactor
+// replacements are converted to the type of the value they replace.
+func (d *Dabugger) redactCopy(v any) any {
+	rv := reflect.ValueOf(d.redactValue("", v))
+	if !rv.IsValid() {
+		return nil
+	}
+
+	r := redactCopier{p: d.newPrinter(), seen: map[visit]reflect.Value{}}
+	return r.copy(rv).Interface()
+}
+
+type redactCopier struct {
+	p *printer
+	// seen maps copied pointers to their copies so cycles are kept
+	seen map[visit]reflect.Value
+}
+
+func (r *redactCopier) copy(v reflect.Value) reflect.Value {
+	t := v.Type()
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := visit{ptr: v.Pointer(), typ: t}
+		if c, ok := r.seen[key]; ok {
+			return c
+		}
+		c := reflect.New(t.Elem())
+		r.seen[key] = c
+		c.Elem().Set(r.copy(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(t).Elem()
+		c.Set(r.copy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(t).Elem()
+		c.Set(v)
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			// unexported fields are kept as copied unless redacted, they
+			// are set through their address in the copy
+			fv := c.Field(i)
+			if !f.IsExported() {
+				fv = reflect.NewAt(f.Type, unsafe.Pointer(fv.UnsafeAddr())).Elem()
+			}
+
+			if f.Tag.Get("dabug") == "redact" || r.p.redacted(f.Name) {
+				fv.Set(redactedValue(f.Type))
+				continue
+			}
+			var val any
+			if f.IsExported() {
+				val = v.Field(i).Interface()
+			}
+			if rv, ok := r.replace(f.Name, val, f.Type); ok {
+				fv.Set(rv)
+			} else if f.IsExported() {
+				fv.Set(r.copy(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(t, v.Len(), v.Len())
+		r.copyElems(c, v)
+		return c
+	case reflect.Array:
+		c := reflect.New(t).Elem()
+		r.copyElems(c, v)
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(t, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			k, val := iter.Key(), iter.Value()
+			if k.Kind() == reflect.String && r.p.redacted(k.String()) {
+				c.SetMapIndex(k, redactedValue(t.Elem()))
+				continue
+			}
+			if k.Kind() == reflect.String {
+				if rv, ok := r.replace(k.String(), val.Interface(), t.Elem()); ok {
+					c.SetMapIndex(k, rv)
+					continue
+				}
+			}
+			c.SetMapIndex(k, r.copy(val))
+		}
+		return c
+	}
+	return v
+}
+
+// copyElems copies the elements of v to c.
+func (r *redactCopier) copyElems(c, v reflect.Value) {
+	switch v.Type().Elem().Kind() {
+	case reflect.Bool, reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		reflect.Copy(c, v)
+		return
+	}
+	for i := 0; i < v.Len(); i++ {
+		c.Index(i).Set(r.copy(v.Index(i)))
+	}
+}
+
+// replace returns the Redactor replacement of type t for v named key.
+// Unexported struct fields are passed with a nil value as they are to Objs.
+func (r *redactCopier) replace(key string, v any, t reflect.Type) (reflect.Value, bool) {
+	if r.p.redactor == nil {
+		return reflect.Value{}, false
+	}
+	if rv, ok := r.p.redactor(key, v); ok {
+		return fitValue(reflect.ValueOf(rv), t), true
+	}
+	return reflect.Value{}, false
+}
+
+// fitValue converts the replacement v to t, strings are formatted and
+// values that do not fit are replaced by the zero value of t.
+func fitValue(v reflect.Value, t reflect.Type) reflect.Value {
+	switch {
+	case !v.IsValid():
+		return reflect.Zero(t)
+	case v.Type().AssignableTo(t):
+		c := reflect.New(t).Elem()
+		c.Set(v)
+		return c
+	case t.Kind() == reflect.String:
+		return reflect.ValueOf(fmt.Sprint(v.Interface())).Convert(t)
+	}
+	return reflect.Zero(t)
+}
+
+// redactedValue is the value of type t that replaces redacted values.
+func redactedValue(t reflect.Type) reflect.Value {
+	return fitValue(reflect.ValueOf(redacted), t)
+}
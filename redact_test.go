@@ -0,0 +1,135 @@
+package dabug
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedact(t *testing.T) {
+	type creds struct {
+		User     string
+		Password string `dabug:"redact"`
+		Token    string
+		Nested   map[string]string
+	}
+	c := creds{"dave", "hunter2", "abc123", map[string]string{"api_key": "k", "region": "us"}}
+
+	d, sb := newMsgDabugger()
+	d.Objs(c)
+	d.Redact("token", "API_KEY")
+	d.Objs(c)
+	d.Var("c", &c)
+
+	assert.Equal(t, `[0] dabug.creds{User:"dave", Password:***, Token:"abc123", Nested:map[string]string{"api_key":"k", "region":"us"}}
+[0] dabug.creds{User:"dave", Password:***, Token:***, Nested:map[string]string{"api_key":***, "region":"us"}}
+c = &dabug.creds{User:"dave", Password:***, Token:***, Nested:map[string]string{"api_key":***, "region":"us"}}
+`, sb.String())
+}
+
+func TestSetRedactor(t *testing.T) {
+	type req struct {
+		URL    string
+		Header map[string]string
+		secret string
+	}
+
+	d, sb := newMsgDabugger()
+	d.Format(FormatJSON)
+	d.SetRedactor(func(key string, v any) (any, bool) {
+		switch key {
+		case "auth", "Authorization", "secret":
+			return "[scrubbed]", true
+		case "":
+			if r, ok := v.(req); ok && r.URL == "drop" {
+				return nil, true
+			}
+		}
+		return nil, false
+	})
+
+	d.AddContext("auth", "bearer xyz")
+	d.AddContext("user", "dave")
+	d.Objs(req{"/x", map[string]string{"Authorization": "xyz", "Accept": "*/*"}, "s"}, req{URL: "drop"})
+	d.Var("secret", 42)
+
+	out := sb.String()
+	assert.Contains(t, out, `"context":{"auth":"[scrubbed]","user":"dave"}`)
+	assert.Contains(t, out, `[0] dabug.req{URL:\"/x\", Header:map[string]string{\"Accept\":\"*/*\", \"Authorization\":\"[scrubbed]\"}, secret:\"[scrubbed]\"}, [1] \u003cnil\u003e`)
+	assert.Contains(t, out, `secret = \"[scrubbed]\"`)
+	assert.NotContains(t, out, "xyz")
+
+	d.SetRedactor(nil)
+	sb.Reset()
+	d.Var("secret", 42)
+	assert.Contains(t, sb.String(), "secret = 42")
+}
+
+func TestRedactDumps(t *testing.T) {
+	type creds struct {
+		User     string
+		Password string `dabug:"redact"`
+		Token    string
+		PIN      int
+		key      string
+	}
+	c := []creds{{"dave", "hunter2", "abc123", 1234, "k"}}
+
+	d, sb := newMsgDabugger()
+	d.Redact("token", "pin")
+	d.SetRedactor(func(key string, v any) (any, bool) {
+		if key == "User" {
+			return "[user]", true
+		}
+		return nil, false
+	})
+	d.ObjsJSON(c)
+	d.ObjsYAML(c)
+	d.Table(c)
+	d.Snapshot(&c[0])
+
+	out := sb.String()
+	for _, secret := range []string{"dave", "hunter2", "abc123", "1234"} {
+		assert.NotContains(t, out, secret)
+	}
+	assert.Contains(t, out, `"Password": "***"`)
+	assert.Contains(t, out, `"PIN": 0`)
+	assert.Contains(t, out, `user: '[user]'`)
+	assert.Contains(t, out, `token: '***'`)
+	assert.Equal(t, "hunter2", c[0].Password, "the dumped value must not be modified")
+}
+
+func TestRedactorValueDumps(t *testing.T) {
+	type session struct {
+		User  string
+		Token string
+	}
+	a := session{"dave", "tok-A"}
+	b := session{"dave", "tok-B"}
+
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.SetRedactor(func(key string, v any) (any, bool) {
+		if key == "Token" || key == "token" {
+			return "[scrubbed]", true
+		}
+		return nil, false
+	})
+
+	d.Diff(a, b)
+	d.Diff(map[string]string{"token": "tok-A"}, map[string]string{})
+	d.Deref("s.Token", &a)
+	d.Summarize(map[string]string{"token": "tok-A", "user": "dave"})
+	d.DumpContext(context.WithValue(context.Background(), ctxKey("token"), "tok-CTX"))
+
+	lines := rec.Lines()
+	require.Len(t, lines, 5)
+	assert.Equal(t, "diff: equal", lines[0].Msg)
+	assert.Equal(t, "diff: 1 change\n  - [\"token\"]: \"[scrubbed]\"", lines[1].Msg)
+	assert.Equal(t, `s.Token = "[scrubbed]"`, lines[2].Msg)
+	assert.Equal(t, "map[string]string: len 2\n  values: \"token\":\"[scrubbed]\", \"user\":\"dave\"", lines[3].Msg)
+	assert.Equal(t, "context:\n  value \"token\" (dabug.ctxKey) = \"[scrubbed]\"\n  context.Background", lines[4].Msg)
+}
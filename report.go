@@ -0,0 +1,24 @@
+package dabug
+
+// Report writes the counters, observations, and gauges as tables and resets
+// them, it is called by Flush.
+func Report() {
+	defDabugger.Report()
+}
+
+func (d *Dabugger) Report() {
+	d.statsMutex.Lock()
+	counters, observations, gauges := d.counters, d.observations, d.gauges
+	d.counters, d.observations, d.gauges = nil, nil, nil
+	d.statsMutex.Unlock()
+
+	if len(counters) > 0 {
+		d.appendMsg(countersStr(counters))
+	}
+	if len(observations) > 0 {
+		d.appendMsg(observationsStr(observations))
+	}
+	if len(gauges) > 0 {
+		d.appendMsg(gaugesStr(gauges))
+	}
+}
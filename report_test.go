@@ -0,0 +1,29 @@
+package dabug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	d.Report()
+	assert.Empty(t, rec.Lines())
+
+	d.Count("x")
+	d.Observe("y", time.Millisecond)
+	d.Report()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0].Msg, "x        1")
+	assert.Contains(t, lines[1].Msg, "y            1")
+	assert.Nil(t, d.counters)
+	assert.Nil(t, d.observations)
+}
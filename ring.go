@@ -0,0 +1,112 @@
+package dabug
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+)
+
+// RingWriter is a LineWriter that keeps only the most recent lines in
+// memory, giving flight recorder semantics for always-on instrumentation.
+type RingWriter struct {
+	mu    sync.Mutex
+	lines []Line
+	// next is the index the next line is written to once lines is full
+	next int
+	max  int
+}
+
+// RingSink creates a RingWriter holding up to maxLines lines, use it with
+// Writer or AddWriter.
+func RingSink(maxLines int) *RingWriter {
+	return &RingWriter{max: max(maxLines, 1)}
+}
+
+func (r *RingWriter) WriteLine(l Line) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.lines) < r.max {
+		r.lines = append(r.lines, l)
+		return nil
+	}
+
+	r.lines[r.next] = l
+	r.next = (r.next + 1) % r.max
+	return nil
+}
+
+func (r *RingWriter) Write(p []byte) (int, error) {
+	for _, l := range textLines(p) {
+		r.WriteLine(l)
+	}
+	return len(p), nil
+}
+
+// Lines returns the retained lines from oldest to newest.
+func (r *RingWriter) Lines() []Line {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lines := make([]Line, 0, len(r.lines))
+	lines = append(lines, r.lines[r.next:]...)
+	return append(lines, r.lines[:r.next]...)
+}
+
+// Dump writes the retained lines to w as a section in the default text
+// format.
+func (r *RingWriter) Dump(w io.Writer) error {
+	lines := r.Lines()
+
+	width := 0
+	for _, l := range lines {
+		width = max(width, len(l.header))
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(delimStr(sectionBeg, "ring") + "\n")
+	for i := range lines {
+		sb.WriteString(lineStr(width, &lines[i], textOpts{}) + "\n")
+	}
+	sb.WriteString(delimStr(sectionEnd, "ring") + "\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// DumpOnPanic dumps the retained lines to w if the calling goroutine is
+// panicking, then continues panicking. It must be deferred directly, ie:
+//
+//	defer ring.DumpOnPanic(os.Stderr)
+func (r *RingWriter) DumpOnPanic(w io.Writer) {
+	if p := recover(); p != nil {
+		r.Dump(w)
+		panic(p)
+	}
+}
+
+// DumpOnSignal dumps the retained lines to w every time one of sigs is
+// received, the returned func stops listening.
+func (r *RingWriter) DumpOnSignal(w io.Writer, sigs ...os.Signal) func() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ch:
+				r.Dump(w)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(ch)
+		close(done)
+	}
+}
@@ -0,0 +1,50 @@
+package dabug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRingSink(t *testing.T) {
+	ring := RingSink(3)
+	d := New()
+	d.Writer(ring)
+	for i := 0; i < 5; i++ {
+		d.Msg("msg %d", i)
+	}
+
+	lines := ring.Lines()
+	require.Len(t, lines, 3)
+	for i, l := range lines {
+		assert.Equal(t, []string{"msg 2", "msg 3", "msg 4"}[i], l.Msg)
+	}
+
+	sb := &strings.Builder{}
+	require.NoError(t, ring.Dump(sb))
+	parts := strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 6)
+	assert.Equal(t, sectionBeg+" ring "+sectionBeg, parts[0])
+	assert.Contains(t, parts[1], "ring_test.go:")
+	assert.True(t, strings.HasSuffix(parts[3], "- msg 4"))
+}
+
+func TestRingDumpOnPanic(t *testing.T) {
+	ring := RingSink(3)
+	ring.Write([]byte("before panic\n"))
+
+	sb := &strings.Builder{}
+	assert.PanicsWithValue(t, "boom", func() {
+		defer ring.DumpOnPanic(sb)
+		panic("boom")
+	})
+	assert.Contains(t, sb.String(), "before panic")
+
+	sb.Reset()
+	func() {
+		defer ring.DumpOnPanic(sb)
+	}()
+	assert.Zero(t, sb.Len())
+}
@@ -0,0 +1,45 @@
+//go:build unix
+
+package dabug
+
+import (
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lockedBuilder struct {
+	mu sync.Mutex
+	sb strings.Builder
+}
+
+func (l *lockedBuilder) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.sb.Write(p)
+}
+
+func (l *lockedBuilder) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.sb.String()
+}
+
+func TestRingDumpOnSignal(t *testing.T) {
+	ring := RingSink(3)
+	ring.Write([]byte("on signal\n"))
+
+	out := &lockedBuilder{}
+	stop := ring.DumpOnSignal(out, syscall.SIGUSR1)
+	defer stop()
+
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+	assert.Eventually(t, func() bool {
+		return strings.Contains(out.String(), "on signal")
+	}, 5*time.Second, time.Millisecond)
+}
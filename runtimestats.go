@@ -0,0 +1,32 @@
+package dabug
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// RuntimeStats writes a line with GOMAXPROCS, the number of goroutines and
+// cgo calls, and the last GC pause, ie:
+// `runtime: 8 procs, 12 goroutines, 0 cgo calls, last GC paused 85µs`.
+func RuntimeStats() {
+	defDabugger.RuntimeStats()
+}
+
+func (d *Dabugger) RuntimeStats() {
+	if !d.enabled() {
+		return
+	}
+
+	m := runtime.MemStats{}
+	runtime.ReadMemStats(&m)
+
+	pause := "no GC yet"
+	if m.NumGC > 0 {
+		last := time.Duration(m.PauseNs[(m.NumGC+255)%256])
+		pause = "last GC paused " + fmtDuration(last)
+	}
+
+	d.appendMsg(fmt.Sprintf("runtime: %d procs, %d goroutines, %d cgo calls, %s",
+		runtime.GOMAXPROCS(0), runtime.NumGoroutine(), runtime.NumCgoCall(), pause))
+}
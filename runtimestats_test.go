@@ -0,0 +1,25 @@
+package dabug
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeStats(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	runtime.GC()
+	d.RuntimeStats()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Regexp(t, fmt.Sprintf(`^runtime: %d procs, \d+ goroutines, \d+ cgo calls, last GC paused \S+$`,
+		runtime.GOMAXPROCS(0)), lines[0].Msg)
+	assert.Equal(t, "runtimestats_test.go", lines[0].File)
+}
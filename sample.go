@@ -0,0 +1,28 @@
+package dabug
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Sample returns a Dabugger that writes only a random fraction rate of the
+// lines from a call site, written lines note the rate, ie:
+// `handled request (sampled 1%)`.
+//
+//	dabug.Sample(0.01).Msg("handled %s", r.URL)
+func Sample(rate float64) *Dabugger {
+	return defDabugger.Sample(rate)
+}
+
+func (d *Dabugger) Sample(rate float64) *Dabugger {
+	if rate >= 1 {
+		return d
+	}
+
+	child := d.derive()
+	child.limit = &limiter{
+		allow: func(*site) bool { return rand.Float64() < rate },
+		note:  fmt.Sprintf(" (sampled %.3g%%)", rate*100),
+	}
+	return child
+}
@@ -0,0 +1,35 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSample(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.NoteSuppressed(false)
+
+	for i := 0; i < 1000; i++ {
+		d.Sample(0.1).Msg("request")
+	}
+	lines := rec.Lines()
+	assert.Greater(t, len(lines), 30)
+	assert.Less(t, len(lines), 300)
+	require.NotEmpty(t, lines)
+	assert.Equal(t, "request (sampled 10%)", lines[0].Msg)
+
+	rec.Reset()
+	for i := 0; i < 10; i++ {
+		d.Sample(0).Msg("never")
+		d.Sample(1).Msg("always")
+	}
+	lines = rec.Lines()
+	require.Len(t, lines, 10)
+	assert.Equal(t, "always", lines[0].Msg)
+
+	assert.Equal(t, " (sampled 33.3%)", d.Sample(1.0/3).limit.note)
+}
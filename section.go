@@ -0,0 +1,59 @@
+package dabug
+
+import (
+	"fmt"
+	"time"
+)
+
+// openSection is a nested section that has not been ended yet.
+type openSection struct {
+	name  string
+	start time.Time
+}
+
+// BeginSection starts a nested section, lines captured until the matching
+// EndSection are indented one level deeper.
+func BeginSection(name string) {
+	defDabugger.BeginSection(name)
+}
+
+func (d *Dabugger) BeginSection(name string) {
+	d.appendMsg(fmt.Sprintf("%s %s", d.sectionBeg, name))
+
+	d.linesMutex.Lock()
+	defer d.linesMutex.Unlock()
+
+	d.sections = append(d.sections, openSection{name: name, start: time.Now()})
+}
+
+// EndSection ends the most recently started nested section, the wall time
+// spent in the section is included in the end delimiter, ie:
+// `===== parseConfig (34ms)`.
+func EndSection() {
+	defDabugger.EndSection()
+}
+
+func (d *Dabugger) EndSection() {
+	d.linesMutex.Lock()
+	if len(d.sections) == 0 {
+		d.linesMutex.Unlock()
+		return
+	}
+	s := d.sections[len(d.sections)-1]
+	d.sections = d.sections[:len(d.sections)-1]
+	d.linesMutex.Unlock()
+
+	d.appendMsg(fmt.Sprintf("%s %s (%s)", d.sectionEnd, s.name, fmtDuration(time.Since(s.start))))
+}
+
+// Scoped starts a nested section and returns a func that ends it, ie:
+//
+//	defer dabug.Scoped("parseConfig")()
+func Scoped(name string) func() {
+	return defDabugger.Scoped(name)
+}
+
+func (d *Dabugger) Scoped(name string) func() {
+	d.BeginSection(name)
+	return d.EndSection
+}
@@ -0,0 +1,72 @@
+package dabug
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSections(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.AutoFlush(false)
+
+	d.Msg("top")
+	func() {
+		defer d.Scoped("outer")()
+		d.Msg("one")
+		d.BeginSection("inner")
+		d.Msg("two")
+		d.EndSection()
+	}()
+	d.EndSection()
+	d.Msg("bottom")
+	d.Flush()
+
+	parts := strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 11)
+
+	msgs := []string{
+		"top",
+		sectionBeg + " outer",
+		indent + "one",
+		indent + sectionBeg + " inner",
+		indent + indent + "two",
+		indent + sectionEnd + " inner (",
+		sectionEnd + " outer (",
+		"bottom",
+	}
+	for i, msg := range msgs {
+		assert.Contains(t, parts[i+1], "section_test.go:")
+		_, got, _ := strings.Cut(parts[i+1], "- ")
+		if strings.HasSuffix(msg, "(") {
+			assert.Regexp(t, `^`+regexp.QuoteMeta(msg)+`\S+\)$`, got, "line %d", i)
+		} else {
+			assert.Equal(t, msg, got, "line %d", i)
+		}
+	}
+}
+
+func TestSectionTiming(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	func() {
+		defer d.Scoped("parseConfig")()
+		time.Sleep(5 * time.Millisecond)
+	}()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^=+ parseConfig \((\d+(\.\d+)?ms)\)$`, lines[1].Msg)
+
+	took, err := time.ParseDuration(strings.TrimSuffix(strings.SplitN(lines[1].Msg, "(", 2)[1], ")"))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, took, 5*time.Millisecond)
+}
@@ -0,0 +1,112 @@
+package dabug
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// SizeOf appends a line with the approximate memory retained by v including
+// everything reachable through pointers, slices, maps, and interfaces, ie:
+// `size of *cache.Cache: 50.0 MiB`. Memory shared by several references is
+// counted once, allocator and map bucket overhead is not included. SizeOf
+// returns 0 without walking v when output is disabled.
+func SizeOf(v any) Bytes {
+	return defDabugger.SizeOf(v)
+}
+
+func (d *Dabugger) SizeOf(v any) Bytes {
+	if !d.enabled() {
+		return 0
+	}
+
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		d.appendMsg("size of <nil>: 0 B")
+		return 0
+	}
+
+	s := sizer{seen: map[visit]bool{}}
+	size := Bytes(rv.Type().Size()) + s.deep(rv)
+	d.appendMsg(fmt.Sprintf("size of %s: %s", rv.Type(), size))
+
+	return size
+}
+
+// sizer sums the memory referenced by values, seen contains the pointers
+// that have already been counted.
+type sizer struct {
+	seen map[visit]bool
+}
+
+// deep returns the size of the memory referenced by v, excluding the size
+// of v itself which is counted by its parent.
+func (s *sizer) deep(v reflect.Value) Bytes {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || !s.first(v) {
+			return 0
+		}
+		return Bytes(v.Type().Elem().Size()) + s.deep(v.Elem())
+	case reflect.Interface:
+		if v.IsNil() {
+			return 0
+		}
+		e := v.Elem()
+		switch e.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+			// stored directly in the interface
+			return s.deep(e)
+		}
+		return Bytes(e.Type().Size()) + s.deep(e)
+	case reflect.String:
+		return Bytes(v.Len())
+	case reflect.Slice:
+		if v.IsNil() || !s.first(v) {
+			return 0
+		}
+		size := Bytes(v.Cap()) * Bytes(v.Type().Elem().Size())
+		for i := 0; i < v.Len(); i++ {
+			size += s.deep(v.Index(i))
+		}
+		return size
+	case reflect.Array:
+		var size Bytes
+		for i := 0; i < v.Len(); i++ {
+			size += s.deep(v.Index(i))
+		}
+		return size
+	case reflect.Struct:
+		var size Bytes
+		for i := 0; i < v.NumField(); i++ {
+			size += s.deep(v.Field(i))
+		}
+		return size
+	case reflect.Map:
+		if v.IsNil() || !s.first(v) {
+			return 0
+		}
+		size := Bytes(v.Len()) * Bytes(v.Type().Key().Size()+v.Type().Elem().Size())
+		iter := v.MapRange()
+		for iter.Next() {
+			size += s.deep(iter.Key()) + s.deep(iter.Value())
+		}
+		return size
+	case reflect.Chan:
+		if v.IsNil() || !s.first(v) {
+			return 0
+		}
+		return Bytes(v.Cap()) * Bytes(v.Type().Elem().Size())
+	}
+
+	return 0
+}
+
+// first reports whether v is seen for the first time.
+func (s *sizer) first(v reflect.Value) bool {
+	key := visit{v.Pointer(), v.Type()}
+	if s.seen[key] {
+		return false
+	}
+	s.seen[key] = true
+	return true
+}
@@ -0,0 +1,42 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSizeOf(t *testing.T) {
+	type cache struct {
+		buf  []byte
+		name string
+		next *cache
+	}
+
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	c := &cache{buf: make([]byte, 10, 3*1024*1024), name: "abcd"}
+	c.next = c
+
+	assert.Equal(t, Bytes(0), d.SizeOf(nil))
+	assert.Equal(t, Bytes(8), d.SizeOf(int64(1)))
+	// the pointer, the struct, the buffer capacity and the string bytes
+	assert.Equal(t, Bytes(8+48+3*1024*1024+4), d.SizeOf(c))
+
+	shared := []byte("12345678")
+	assert.Equal(t, Bytes(2*24+8), d.SizeOf([2][]byte{shared, shared}))
+
+	m := map[string]int{"ab": 1}
+	assert.Equal(t, Bytes(8+16+8+2), d.SizeOf(m))
+	// the slice header, an interface element, and the boxed string
+	assert.Equal(t, Bytes(24+16+16+3), d.SizeOf([]any{"abc"}))
+
+	lines := rec.Lines()
+	require.Len(t, lines, 6)
+	assert.Equal(t, "size of <nil>: 0 B", lines[0].Msg)
+	assert.Equal(t, "size of int64: 8 B", lines[1].Msg)
+	assert.Equal(t, "size of *dabug.cache: 3.0 MiB", lines[2].Msg)
+}
@@ -0,0 +1,211 @@
+package dabug
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Snapshot appends a line with v rendered as a Go literal that can be
+// pasted into a test, ie:
+//
+//	&config.Config{
+//		Name: "dave",
+//		Port: func() *int { v := 8080; return &v }(),
+//	}
+//
+// Zero valued fields are omitted. Channels, funcs, and pointers that refer
+// back to a value being rendered have no literal form and are written as
+// nil with a comment.
+func Snapshot(v any) {
+	defDabugger.Snapshot(v)
+}
+
+func (d *Dabugger) Snapshot(v any) {
+	if !d.enabled() {
+		return
+	}
+
+	s := snapshotter{visiting: map[visit]bool{}}
+	s.value(reflect.ValueOf(d.redactCopy(v)), 0, false)
+	d.appendMsg(s.sb.String())
+}
+
+type snapshotter struct {
+	sb       strings.Builder
+	visiting map[visit]bool
+}
+
+// value writes v, typed reports whether the surrounding code already
+// determines the type of v so untyped constants can be used.
+func (s *snapshotter) value(v reflect.Value, depth int, typed bool) {
+	if !v.IsValid() {
+		s.sb.WriteString("nil")
+		return
+	}
+
+	t := v.Type()
+	switch v.Kind() {
+	case reflect.Bool, reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		lit := basicLit(v)
+		if typed || t == reflect.TypeOf(lit.def) {
+			s.sb.WriteString(lit.s)
+		} else {
+			fmt.Fprintf(&s.sb, "%s(%s)", t, lit.s)
+		}
+	case reflect.Interface:
+		s.value(v.Elem(), depth, false)
+	case reflect.Pointer:
+		s.pointer(v, depth, typed)
+	case reflect.Struct:
+		var fields []int
+		for i := 0; i < v.NumField(); i++ {
+			if !v.Field(i).IsZero() {
+				fields = append(fields, i)
+			}
+		}
+		s.items(t.String(), len(fields), depth, func(i int) {
+			f := fields[i]
+			s.sb.WriteString(t.Field(f).Name + ": ")
+			s.value(v.Field(f), depth+1, true)
+		})
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			s.nilValue(t, typed, "")
+			return
+		}
+		s.items(typeStr(t), v.Len(), depth, func(i int) {
+			s.value(v.Index(i), depth+1, true)
+		})
+	case reflect.Map:
+		if v.IsNil() {
+			s.nilValue(t, typed, "")
+			return
+		}
+		if !s.enter(v) {
+			s.nilValue(t, typed, "cycle")
+			return
+		}
+		defer s.leave(v)
+
+		keys := v.MapKeys()
+		slices.SortFunc(keys, compareValues)
+		s.items(t.String(), len(keys), depth, func(i int) {
+			s.value(keys[i], depth+1, true)
+			s.sb.WriteString(": ")
+			s.value(v.MapIndex(keys[i]), depth+1, true)
+		})
+	default:
+		s.nilValue(t, typed, t.String())
+	}
+}
+
+func (s *snapshotter) pointer(v reflect.Value, depth int, typed bool) {
+	t := v.Type()
+	if v.IsNil() {
+		s.nilValue(t, typed, "")
+		return
+	}
+	if !s.enter(v) {
+		s.nilValue(t, typed, "cycle")
+		return
+	}
+	defer s.leave(v)
+
+	switch t.Elem().Kind() {
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		s.sb.WriteString("&")
+		s.value(v.Elem(), depth, false)
+	default:
+		fmt.Fprintf(&s.sb, "func() %s { v := ", t)
+		s.value(v.Elem(), depth, false)
+		s.sb.WriteString("; return &v }()")
+	}
+}
+
+// nilValue writes a nil of type t with an optional comment explaining why.
+func (s *snapshotter) nilValue(t reflect.Type, typed bool, comment string) {
+	if typed {
+		s.sb.WriteString("nil")
+	} else {
+		fmt.Fprintf(&s.sb, "(%s)(nil)", t)
+	}
+	if comment != "" {
+		fmt.Fprintf(&s.sb, " /* %s */", comment)
+	}
+}
+
+// items writes a composite literal of n elements one per line.
+func (s *snapshotter) items(typ string, n, depth int, item func(i int)) {
+	s.sb.WriteString(typ + "{")
+	if n == 0 {
+		s.sb.WriteString("}")
+		return
+	}
+	for i := 0; i < n; i++ {
+		s.sb.WriteString("\n" + strings.Repeat("\t", depth+1))
+		item(i)
+		s.sb.WriteString(",")
+	}
+	s.sb.WriteString("\n" + strings.Repeat("\t", depth) + "}")
+}
+
+func (s *snapshotter) enter(v reflect.Value) bool {
+	key := visit{v.Pointer(), v.Type()}
+	if s.visiting[key] {
+		return false
+	}
+	s.visiting[key] = true
+	return true
+}
+
+func (s *snapshotter) leave(v reflect.Value) {
+	delete(s.visiting, visit{v.Pointer(), v.Type()})
+}
+
+// literal is the source of a basic value, def is a value of the type the
+// literal has when untyped.
+type literal struct {
+	s   string
+	def any
+}
+
+func basicLit(v reflect.Value) literal {
+	switch v.Kind() {
+	case reflect.Bool:
+		return literal{strconv.FormatBool(v.Bool()), false}
+	case reflect.String:
+		return literal{strconv.Quote(v.String()), ""}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return literal{strconv.FormatInt(v.Int(), 10), 0}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return literal{strconv.FormatUint(v.Uint(), 10), 0}
+	case reflect.Float32, reflect.Float64:
+		return literal{floatLit(v.Float(), v.Type().Bits()), 0.0}
+	}
+
+	c := v.Complex()
+	return literal{fmt.Sprintf("complex(%s, %s)", floatLit(real(c), 64), floatLit(imag(c), 64)), 0i}
+}
+
+func floatLit(f float64, bits int) string {
+	switch {
+	case math.IsNaN(f):
+		return "math.NaN()"
+	case math.IsInf(f, 1):
+		return "math.Inf(1)"
+	case math.IsInf(f, -1):
+		return "math.Inf(-1)"
+	}
+
+	s := strconv.FormatFloat(f, 'g', -1, bits)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
@@ -0,0 +1,79 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	type address struct {
+		City string
+		Zip  *int
+	}
+	type person struct {
+		Name    string
+		Age     uint8
+		Score   float64
+		Address *address
+		Tags    map[string][]int
+		Extra   any
+		Skipped string
+	}
+
+	zip := 12345
+	p := &person{
+		Name:    "dave",
+		Age:     40,
+		Score:   3,
+		Address: &address{City: "earth", Zip: &zip},
+		Tags:    map[string][]int{"b": {2}, "a": {}},
+		Extra:   int64(7),
+	}
+
+	d, sb := newMsgDabugger()
+	d.Snapshot(p)
+	d.Snapshot(nil)
+	d.Snapshot(uint(3))
+	d.Snapshot([]string(nil))
+	d.Snapshot(struct{ C chan int }{make(chan int)})
+
+	assert.Equal(t, `&dabug.person{
+	Name: "dave",
+	Age: 40,
+	Score: 3.0,
+	Address: &dabug.address{
+		City: "earth",
+		Zip: func() *int { v := 12345; return &v }(),
+	},
+	Tags: map[string][]int{
+		"a": []int{},
+		"b": []int{
+			2,
+		},
+	},
+	Extra: int64(7),
+}
+nil
+uint(3)
+([]string)(nil)
+struct { C chan int }{
+	C: nil /* chan int */,
+}
+`, sb.String())
+}
+
+func TestSnapshotCycle(t *testing.T) {
+	n := &prettyNode{Name: "a"}
+	n.Next = n
+
+	d, sb := newMsgDabugger()
+	d.Snapshot(n)
+	assert.Equal(t, "&dabug.prettyNode{\n\tName: \"a\",\n\tNext: nil /* cycle */,\n}\n", sb.String())
+}
+
+func TestFloatLit(t *testing.T) {
+	assert.Equal(t, "1.0", floatLit(1, 64))
+	assert.Equal(t, "1.5", floatLit(1.5, 64))
+	assert.Equal(t, "1e+21", floatLit(1e21, 64))
+}
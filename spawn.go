@@ -0,0 +1,82 @@
+package dabug
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// goroutineContexts maps goroutine ids to the contexts inherited with Go.
+var goroutineContexts sync.Map
+
+// Go runs fn in a new goroutine that inherits the context pairs and the
+// goroutine label of the caller, so lines of the worker keep the context
+// even after the caller removes it, ie:
+//
+//	defer dabug.Context("request", id)()
+//	dabug.Go(func() { process(job) })
+func Go(fn func()) {
+	defDabugger.Go(fn)
+}
+
+func (d *Dabugger) Go(fn func()) {
+	id := goid()
+	ctx := d.lineContexts(id)
+	label := goroutineLabel(id)
+
+	go func() {
+		defer inheritContexts(ctx, label)()
+		fn()
+	}()
+}
+
+// GoCtx runs fn with ctx in a new goroutine as with Go.
+func GoCtx(ctx context.Context, fn func(ctx context.Context)) {
+	defDabugger.GoCtx(ctx, fn)
+}
+
+func (d *Dabugger) GoCtx(ctx context.Context, fn func(ctx context.Context)) {
+	d.Go(func() { fn(ctx) })
+}
+
+// inheritContexts sets the inherited contexts and label of the calling
+// goroutine, the returned func removes them.
+func inheritContexts(ctx Fields, label string) func() {
+	id := goid()
+	if len(ctx) > 0 {
+		goroutineContexts.Store(id, ctx)
+	}
+	unlabel := func() {}
+	if label != "" {
+		unlabel = LabelGoroutine(label)
+	}
+
+	return func() {
+		goroutineContexts.Delete(id)
+		unlabel()
+	}
+}
+
+// pushContexts adds ctx to the inherited contexts of the calling goroutine
+// only, the returned func restores the previous inherited contexts.
+func pushContexts(ctx Fields) func() {
+	id := goid()
+	prev := inheritedContexts(id)
+	goroutineContexts.Store(id, append(slices.Clip(prev), ctx...))
+
+	return func() {
+		if prev == nil {
+			goroutineContexts.Delete(id)
+			return
+		}
+		goroutineContexts.Store(id, prev)
+	}
+}
+
+// inheritedContexts returns the contexts inherited by the goroutine with id.
+func inheritedContexts(id int64) Fields {
+	if ctx, ok := goroutineContexts.Load(id); ok {
+		return ctx.(Fields)
+	}
+	return nil
+}
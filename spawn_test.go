@@ -0,0 +1,66 @@
+package dabug
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGo(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.AddContext("service", "api")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	started := make(chan struct{})
+	func() {
+		defer LabelGoroutine("handler")()
+		defer d.Context("request", "7")()
+		d.With("user", "bob").Go(func() {
+			defer wg.Done()
+			<-started
+			d.Msg("worker")
+
+			d.GoCtx(context.Background(), func(ctx context.Context) {
+				defer wg.Done()
+				assert.NotNil(t, ctx)
+				d.Msg("nested")
+			})
+		})
+	}()
+	close(started)
+	wg.Wait()
+	d.Msg("parent")
+
+	lines := rec.Lines()
+	require.Len(t, lines, 3)
+	for _, l := range lines[:2] {
+		assert.Equal(t, Fields{{"service", "api"}, {"request", "7"}, {"user", "bob"}}, l.Context, l.Msg)
+		assert.Equal(t, "handler", l.GoroutineLabel)
+	}
+	assert.Equal(t, Fields{{"service", "api"}}, lines[2].Context)
+	assert.Empty(t, lines[2].GoroutineLabel)
+}
+
+func TestGoWith(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	rd := d.With("req", "1")
+	done := make(chan struct{})
+	rd.Go(func() {
+		defer close(done)
+		rd.Msg("worker")
+	})
+	<-done
+
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Equal(t, Fields{{"req", "1"}}, lines[0].Context)
+}
@@ -0,0 +1,135 @@
+package dabug
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// summarySamples is the number of leading and trailing elements Summarize
+// shows.
+const summarySamples = 3
+
+// Summarize appends a line describing a slice, array, or map instead of
+// every element, with the length, capacity, element type, the first and last
+// few elements, and the min, max, and mean of numeric elements, ie:
+//
+//	[]int: len 10000, cap 16384
+//	  head: 1, 2, 3
+//	  tail: 9998, 9999, 10000
+//	  min 1, max 10000, mean 5000.5
+//
+// Map entries are sampled in sorted key order and stats use the values,
+// other values are rendered as with Objs.
+func Summarize(v any) {
+	defDabugger.Summarize(v)
+}
+
+func (d *Dabugger) Summarize(v any) {
+	if !d.enabled() {
+		return
+	}
+
+	rv := reflect.ValueOf(d.redactValue("", v))
+
+	var elems []reflect.Value
+	var render func(i int) string
+	header := ""
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		header = fmt.Sprintf("%s: len %d, cap %d", typeStr(rv.Type()), rv.Len(), rv.Cap())
+		for i := 0; i < rv.Len(); i++ {
+			elems = append(elems, rv.Index(i))
+		}
+		render = func(i int) string { return d.prettyValue(elems[i]) }
+	case reflect.Map:
+		header = fmt.Sprintf("%s: len %d", rv.Type(), rv.Len())
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, compareValues)
+		for _, k := range keys {
+			e := rv.MapIndex(k)
+			if k.Kind() == reflect.String && d.redacted(k.String()) {
+				// also keeps the redacted values out of the stats
+				e = reflect.ValueOf(redacted)
+			} else if k.Kind() == reflect.String {
+				e = d.redactField(k.String(), e)
+			}
+			elems = append(elems, e)
+		}
+		render = func(i int) string {
+			if keys[i].Kind() == reflect.String && d.redacted(keys[i].String()) {
+				return d.prettyValue(keys[i]) + ":" + redacted
+			}
+			return d.prettyValue(keys[i]) + ":" + d.prettyValue(elems[i])
+		}
+	default:
+		d.appendMsg(d.objStr(v))
+		return
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(header)
+
+	samples := func(name string, beg, end int) {
+		var s []string
+		for i := beg; i < end; i++ {
+			s = append(s, d.truncate(render(i)))
+		}
+		sb.WriteString(fmt.Sprintf("\n%s%s: %s", indent, name, strings.Join(s, ", ")))
+	}
+	switch n := len(elems); {
+	case n == 0:
+	case n <= 2*summarySamples:
+		samples("values", 0, n)
+	default:
+		samples("head", 0, summarySamples)
+		samples("tail", n-summarySamples, n)
+	}
+
+	if stats, ok := numericStats(elems); ok {
+		sb.WriteString("\n" + indent + stats)
+	}
+
+	d.appendMsg(sb.String())
+}
+
+// numericStats returns the min, max, and mean of elems if they are all
+// numbers.
+func numericStats(elems []reflect.Value) (string, bool) {
+	if len(elems) == 0 {
+		return "", false
+	}
+
+	lo, hi, sum := 0.0, 0.0, 0.0
+	for i, e := range elems {
+		for e.Kind() == reflect.Interface && !e.IsNil() {
+			e = e.Elem()
+		}
+
+		var f float64
+		switch e.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			f = float64(e.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			f = float64(e.Uint())
+		case reflect.Float32, reflect.Float64:
+			f = e.Float()
+		default:
+			return "", false
+		}
+
+		if i == 0 || f < lo {
+			lo = f
+		}
+		if i == 0 || f > hi {
+			hi = f
+		}
+		sum += f
+	}
+
+	g := func(f float64) string { return strconv.FormatFloat(f, 'g', 6, 64) }
+	return fmt.Sprintf("min %s, max %s, mean %s", g(lo), g(hi), g(sum/float64(len(elems)))), true
+}
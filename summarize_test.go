@@ -0,0 +1,51 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarize(t *testing.T) {
+	big := make([]int, 0, 16384)
+	for i := 1; i <= 10000; i++ {
+		big = append(big, i)
+	}
+
+	d, sb := newMsgDabugger()
+	d.Summarize(big)
+	d.Summarize(map[string]float64{"b": 2, "a": 1.5})
+	d.Summarize([]string{})
+	d.Summarize([2]any{"x", 1})
+	d.Summarize(12)
+
+	assert.Equal(t, `[]int: len 10000, cap 16384
+  head: 1, 2, 3
+  tail: 9998, 9999, 10000
+  min 1, max 10000, mean 5000.5
+map[string]float64: len 2
+  values: "a":1.5, "b":2
+  min 1.5, max 2, mean 1.75
+[]string: len 0, cap 0
+[2]interface {}: len 2, cap 2
+  values: "x", 1
+12
+`, sb.String())
+}
+
+func TestSummarizeRedact(t *testing.T) {
+	type creds struct {
+		Password string `dabug:"redact"`
+	}
+
+	d, sb := newMsgDabugger()
+	d.Redact("pin")
+	d.Summarize(map[string]int{"pin": 1234, "port": 80})
+	d.Summarize([]creds{{"hunter2"}})
+
+	assert.Equal(t, `map[string]int: len 2
+  values: "pin":***, "port":80
+[]dabug.creds: len 1, cap 1
+  values: dabug.creds{Password:***}
+`, sb.String())
+}
@@ -0,0 +1,45 @@
+package dabug
+
+import "fmt"
+
+// Summary writes a line after each flushed section with the number of
+// lines, the wall time they span, and the number of distinct call sites.
+func Summary(enabled bool) {
+	defDabugger.Summary(enabled)
+}
+
+func (d *Dabugger) Summary(enabled bool) {
+	d.summary = enabled
+}
+
+func summaryStr(lines []*Line) string {
+	if len(lines) == 0 {
+		return "0 lines"
+	}
+
+	n := 0
+	sites := map[Source]struct{}{}
+	first, last := lines[0].Time, lines[0].Time
+	for _, l := range lines {
+		n += max(l.repeats, 1)
+		sites[l.Source] = struct{}{}
+		if l.Time.Before(first) {
+			first = l.Time
+		}
+		if l.Time.After(last) {
+			last = l.Time
+		}
+	}
+
+	return fmt.Sprintf("%d %s in %s from %d %s",
+		n, plural(n, "line", "lines"),
+		fmtDuration(last.Sub(first)),
+		len(sites), plural(len(sites), "call site", "call sites"))
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
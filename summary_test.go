@@ -0,0 +1,49 @@
+package dabug
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummary(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.LinePrefix("")
+	d.AutoFlush(false)
+	d.Summary(true)
+
+	for i := 0; i < 3; i++ {
+		d.Msg("loop")
+	}
+	d.Msg("after")
+	d.Flush()
+
+	parts := strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 6)
+	assert.True(t, strings.HasSuffix(parts[1], "- loop ×3"), parts[1])
+	assert.Equal(t, sectionEnd, parts[3])
+	assert.Regexp(t, `^4 lines in \S+ from 2 call sites$`, parts[4])
+
+	sb.Reset()
+	d.AlignColumns(true)
+	d.Msg("one")
+	d.Flush()
+	parts = strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 5)
+	assert.Equal(t, "1 line in 0s from 1 call site", parts[3])
+}
+
+func TestSummaryStr(t *testing.T) {
+	now := time.Now()
+	lines := []*Line{
+		{Source: Source{File: "a.go", Line: 1}, Time: now},
+		{Source: Source{File: "a.go", Line: 2}, Time: now.Add(2 * time.Second)},
+		{Source: Source{File: "a.go", Line: 1}, Time: now.Add(time.Second)},
+	}
+	assert.Equal(t, "3 lines in 2s from 2 call sites", summaryStr(lines))
+}
@@ -0,0 +1,31 @@
+package dabug
+
+type syncer interface {
+	Sync() error
+}
+
+// SyncOnFlush commits output to stable storage after every flush, including
+// auto flushed lines, for writers with a Sync method such as *os.File.
+// Sync errors are reported to the OnWriteError func.
+func SyncOnFlush(enabled bool) {
+	defDabugger.SyncOnFlush(enabled)
+}
+
+func (d *Dabugger) SyncOnFlush(enabled bool) {
+	d.syncOnFlush = enabled
+}
+
+func (d *Dabugger) sync(w any) {
+	if !d.syncOnFlush {
+		return
+	}
+
+	s, ok := w.(syncer)
+	if !ok {
+		return
+	}
+
+	if err := s.Sync(); err != nil {
+		d.writeFailed(err, 0)
+	}
+}
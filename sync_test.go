@@ -0,0 +1,46 @@
+package dabug
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type syncWriter struct {
+	strings.Builder
+	syncs int
+	err   error
+}
+
+func (s *syncWriter) Sync() error {
+	s.syncs++
+	return s.err
+}
+
+func TestSyncOnFlush(t *testing.T) {
+	w := &syncWriter{}
+	d := New()
+	d.Writer(w)
+	d.AutoFlush(false)
+	d.Msg("msg")
+	d.Flush()
+	assert.Zero(t, w.syncs)
+
+	d.SyncOnFlush(true)
+	d.Msg("msg")
+	d.Flush()
+	assert.Equal(t, 1, w.syncs)
+
+	d.AutoFlush(true)
+	d.Msg("msg")
+	assert.Equal(t, 2, w.syncs)
+
+	var errs []error
+	d.OnWriteError(func(err error) { errs = append(errs, err) })
+	w.err = errors.New("sync failed")
+	d.Msg("msg")
+	assert.Equal(t, []error{w.err}, errs)
+	assert.Zero(t, d.Dropped())
+}
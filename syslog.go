@@ -0,0 +1,57 @@
+//go:build !windows && !plan9
+
+package dabug
+
+import (
+	"bytes"
+	"io"
+	"log/syslog"
+)
+
+// SyslogMode controls how output is split into syslog entries.
+type SyslogMode int
+
+const (
+	// SyslogPerSection writes each flushed section, or auto flushed line,
+	// as a single entry.
+	SyslogPerSection SyslogMode = iota
+	// SyslogPerLine writes an entry for every line.
+	SyslogPerLine
+)
+
+// SyslogWriter writes output to the system logger.
+type SyslogWriter struct {
+	w    io.WriteCloser
+	mode SyslogMode
+}
+
+// SyslogSink connects to the local syslog daemon, use it with Writer or
+// AddWriter.
+func SyslogSink(priority syslog.Priority, tag string, mode SyslogMode) (*SyslogWriter, error) {
+	w, err := syslog.New(priority, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SyslogWriter{w: w, mode: mode}, nil
+}
+
+func (s *SyslogWriter) Write(p []byte) (int, error) {
+	if s.mode == SyslogPerSection {
+		if _, err := s.w.Write(bytes.TrimSuffix(p, []byte("\n"))); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	for _, l := range bytes.Split(bytes.TrimSuffix(p, []byte("\n")), []byte("\n")) {
+		if _, err := s.w.Write(l); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (s *SyslogWriter) Close() error {
+	return s.w.Close()
+}
@@ -0,0 +1,46 @@
+//go:build !windows && !plan9
+
+package dabug
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type entryRecorder struct {
+	entries []string
+}
+
+func (e *entryRecorder) Write(p []byte) (int, error) {
+	e.entries = append(e.entries, string(p))
+	return len(p), nil
+}
+
+func (e *entryRecorder) Close() error {
+	return nil
+}
+
+func TestSyslogWriter(t *testing.T) {
+	for _, mode := range []SyslogMode{SyslogPerSection, SyslogPerLine} {
+		rec := &entryRecorder{}
+		d := New()
+		d.Writer(&SyslogWriter{w: rec, mode: mode})
+		d.AutoFlush(false)
+		d.Msg("one")
+		d.Msg("two")
+		d.Flush()
+
+		if mode == SyslogPerSection {
+			assert.Len(t, rec.entries, 1)
+			assert.Equal(t, 3, strings.Count(rec.entries[0], "\n"))
+		} else {
+			assert.Len(t, rec.entries, 4)
+			assert.Contains(t, rec.entries[2], "two")
+		}
+	}
+
+	var _ io.WriteCloser = &SyslogWriter{}
+}
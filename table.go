@@ -0,0 +1,90 @@
+package dabug
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// Table appends a line with slice rendered as an aligned table, structs
+// have a column per field and other elements are rendered in a single
+// value column.
+func Table(slice any) {
+	defDabugger.Table(slice)
+}
+
+func (d *Dabugger) Table(slice any) {
+	if !d.enabled() {
+		return
+	}
+
+	d.appendMsg(d.tableStr(slice))
+}
+
+func (d *Dabugger) tableStr(slice any) string {
+	v := reflect.ValueOf(d.redactCopy(slice))
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return d.objStr(slice)
+	}
+
+	elemType := v.Type().Elem()
+	for elemType.Kind() == reflect.Pointer {
+		elemType = elemType.Elem()
+	}
+
+	header := []string{"#"}
+	if elemType.Kind() == reflect.Struct {
+		for i := 0; i < elemType.NumField(); i++ {
+			header = append(header, elemType.Field(i).Name)
+		}
+	} else {
+		header = append(header, "value")
+	}
+
+	sb := strings.Builder{}
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for i := 0; i < v.Len(); i++ {
+		row := []string{strconv.Itoa(i)}
+
+		e := v.Index(i)
+		for e.Kind() == reflect.Pointer && !e.IsNil() {
+			e = e.Elem()
+		}
+
+		switch {
+		case e.Kind() == reflect.Pointer:
+			row = append(row, "<nil>")
+			for len(row) < len(header) {
+				row = append(row, "")
+			}
+		case elemType.Kind() == reflect.Struct:
+			for f := 0; f < e.NumField(); f++ {
+				row = append(row, d.cellStr(e.Field(f)))
+			}
+		default:
+			row = append(row, d.cellStr(e))
+		}
+
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+
+	rows := strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+	for i, r := range rows {
+		rows[i] = strings.TrimRight(r, " ")
+	}
+
+	return fmt.Sprintf("%s (%d)\n%s", v.Type(), v.Len(), strings.Join(rows, "\n"))
+}
+
+// cellStr renders a table cell on a single line.
+func (d *Dabugger) cellStr(v reflect.Value) string {
+	s := fmt.Sprintf("%v", v)
+	s = strings.ReplaceAll(s, "\t", " ")
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return d.truncate(s)
+}
@@ -0,0 +1,48 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTable(t *testing.T) {
+	type row struct {
+		Name  string
+		Count int
+		notes string
+	}
+
+	d, sb := newMsgDabugger()
+	d.Table([]*row{{"first", 1, "a\nb"}, nil, {"second one", 200, ""}})
+	d.Table([]int{3, 4})
+	d.Table("not a slice")
+
+	assert.Equal(t, "[]*dabug.row (3)\n"+
+		"#  Name        Count  notes\n"+
+		"0  first       1      a\\nb\n"+
+		"1  <nil>\n"+
+		"2  second one  200\n"+
+		"[]int (2)\n"+
+		"#  value\n"+
+		"0  3\n"+
+		"1  4\n"+
+		`"not a slice"`+"\n", sb.String())
+}
+
+func TestTableRedact(t *testing.T) {
+	type creds struct {
+		User     string
+		Password string `dabug:"redact"`
+		Token    string
+	}
+
+	d, sb := newMsgDabugger()
+	d.Redact("token")
+	d.Table([]creds{{"dave", "hunter2", "tok-TABLE"}})
+
+	assert.Equal(t, `[]dabug.creds (1)
+#  User  Password  Token
+0  dave  ***       ***
+`, sb.String())
+}
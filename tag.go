@@ -0,0 +1,56 @@
+package dabug
+
+import (
+	"slices"
+	"strings"
+)
+
+// Tag returns a Dabugger that shares the output of the default Dabugger and
+// records tags with every line it writes, see EnableTags.
+func Tag(tags ...string) *Dabugger {
+	return defDabugger.Tag(tags...)
+}
+
+func (d *Dabugger) Tag(tags ...string) *Dabugger {
+	child := d.derive()
+	child.tags = append(slices.Clip(d.tags), tags...)
+	return child
+}
+
+// EnableTags limits output to lines written by Dabuggers with at least one
+// of the given tags, untagged lines are dropped as well. Call it without
+// tags to write all lines again. The default Dabugger is limited at init to
+// the comma separated tags in the DABUG_TAGS environment variable, ie:
+// `DABUG_TAGS=net,cache`.
+func EnableTags(tags ...string) {
+	defDabugger.EnableTags(tags...)
+}
+
+func (d *Dabugger) EnableTags(tags ...string) {
+	if len(tags) == 0 {
+		d.enabledTags.Store(nil)
+		return
+	}
+
+	set := map[string]bool{}
+	for _, t := range tags {
+		if t = strings.TrimSpace(t); t != "" {
+			set[t] = true
+		}
+	}
+	d.enabledTags.Store(&set)
+}
+
+// tagsEnabled reports whether the tags of d pass the EnableTags filter.
+func (d *Dabugger) tagsEnabled() bool {
+	set := d.enabledTags.Load()
+	if set == nil {
+		return true
+	}
+	for _, t := range d.tags {
+		if (*set)[t] {
+			return true
+		}
+	}
+	return false
+}
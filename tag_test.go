@@ -0,0 +1,56 @@
+package dabug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTag(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	netLog := d.Tag("net")
+	cacheLog := d.Tag("cache")
+	lruLog := cacheLog.Tag("lru")
+
+	d.Msg("untagged")
+	netLog.Msg("net")
+	lruLog.Msg("lru")
+
+	d.EnableTags("cache", " db")
+	d.Msg("untagged")
+	netLog.Msg("net")
+	cacheLog.Msg("cache")
+	lruLog.Msg("lru")
+	assert.False(t, netLog.Enabled())
+
+	d.EnableTags()
+	netLog.Msg("net again")
+
+	lines := rec.Lines()
+	require.Len(t, lines, 6)
+	var msgs []string
+	for _, l := range lines {
+		msgs = append(msgs, l.Msg)
+	}
+	assert.Equal(t, []string{"untagged", "net", "lru", "cache", "lru", "net again"}, msgs)
+	assert.Nil(t, lines[0].Tags)
+	assert.Equal(t, []string{"net"}, lines[1].Tags)
+	assert.Equal(t, []string{"cache", "lru"}, lines[2].Tags)
+	assert.Equal(t, "tag_test.go", lines[2].File)
+}
+
+func TestTagJSON(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.Format(FormatJSON)
+	d.Tag("a", "b").Msg("hi")
+	d.Msg("none")
+	assert.Equal(t, 1, strings.Count(sb.String(), `"tags":["a","b"]`))
+	assert.Equal(t, 1, strings.Count(sb.String(), `"tags"`))
+}
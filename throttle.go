@@ -0,0 +1,25 @@
+package dabug
+
+import "time"
+
+// Throttle returns a Dabugger that writes at most one line per interval
+// from a call site, the next written line notes the calls suppressed in
+// between, ie: `handled request (suppressed 812)`.
+//
+//	dabug.Throttle(time.Second).Msg("handled %s", r.URL)
+func Throttle(interval time.Duration) *Dabugger {
+	return defDabugger.Throttle(interval)
+}
+
+func (d *Dabugger) Throttle(interval time.Duration) *Dabugger {
+	if interval <= 0 {
+		return d
+	}
+
+	child := d.derive()
+	child.limit = &limiter{
+		allow:  func(s *site) bool { return time.Since(s.last) >= interval },
+		inline: true,
+	}
+	return child
+}
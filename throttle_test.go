@@ -0,0 +1,42 @@
+package dabug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottle(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 5; j++ {
+			d.Throttle(50*time.Millisecond).Msg("request %d", i)
+		}
+		time.Sleep(60 * time.Millisecond)
+	}
+	for i := 0; i < 2; i++ {
+		d.Throttle(time.Hour).Msg("once")
+	}
+	d.Throttle(0).Msg("always")
+	d.Throttle(0).Msg("always")
+	d.Flush()
+
+	var msgs []string
+	for _, l := range rec.Lines() {
+		msgs = append(msgs, l.Msg)
+	}
+	require.Equal(t, []string{
+		"request 0",
+		"request 1 (suppressed 4)",
+		"request 2 (suppressed 4)",
+		"once",
+		"always",
+		"always",
+		"suppressed 4 more",
+		"suppressed 1 more",
+	}, msgs)
+}
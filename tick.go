@@ -0,0 +1,80 @@
+package dabug
+
+import (
+	"fmt"
+	"time"
+)
+
+// defTickInterval is how often Tick reports by default.
+const defTickInterval = time.Second
+
+// ticker counts the calls to Tick of a single name.
+type ticker struct {
+	start time.Time
+	calls int
+	total int
+}
+
+// TickEvery sets how often Tick reports the rate, after interval has passed
+// or calls calls have been counted, whichever comes first. Zero disables
+// the respective trigger, by default the rate is reported every second.
+func TickEvery(interval time.Duration, calls int) {
+	defDabugger.TickEvery(interval, calls)
+}
+
+func (d *Dabugger) TickEvery(interval time.Duration, calls int) {
+	d.statsMutex.Lock()
+	defer d.statsMutex.Unlock()
+
+	d.tickInterval = interval
+	d.tickCalls = calls
+}
+
+// Tick counts a call of name and periodically writes a single line with the
+// rate per second, ie: `tick loop: 1,234/s (5,000 total)`. Use it to
+// instrument hot loops without writing a line per iteration.
+func Tick(name string) {
+	defDabugger.Tick(name)
+}
+
+func (d *Dabugger) Tick(name string) {
+	if !d.enabled() {
+		return
+	}
+
+	now := time.Now()
+
+	d.statsMutex.Lock()
+	if d.ticks == nil {
+		d.ticks = map[string]*ticker{}
+		if d.tickInterval == 0 && d.tickCalls == 0 {
+			d.tickInterval = defTickInterval
+		}
+	}
+	t, ok := d.ticks[name]
+	if !ok {
+		t = &ticker{start: now}
+		d.ticks[name] = t
+	}
+	t.calls++
+	t.total++
+
+	elapsed := now.Sub(t.start)
+	due := (d.tickInterval > 0 && elapsed >= d.tickInterval) ||
+		(d.tickCalls > 0 && t.calls >= d.tickCalls)
+	calls, total := t.calls, t.total
+	if due {
+		t.start, t.calls = now, 0
+	}
+	d.statsMutex.Unlock()
+
+	if !due {
+		return
+	}
+
+	rate := "∞"
+	if elapsed > 0 {
+		rate = groupDigits(fmt.Sprintf("%.0f", float64(calls)/elapsed.Seconds()))
+	}
+	d.appendMsg(fmt.Sprintf("tick %s: %s/s (%s total)", name, rate, groupDigits(fmt.Sprint(total))))
+}
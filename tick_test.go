@@ -0,0 +1,51 @@
+package dabug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTickCalls(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.TickEvery(0, 1000)
+
+	for i := 0; i < 2500; i++ {
+		d.Tick("loop")
+	}
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^tick loop: \S+/s \(1,000 total\)$`, lines[0].Msg)
+	assert.Regexp(t, `^tick loop: \S+/s \(2,000 total\)$`, lines[1].Msg)
+	assert.Equal(t, "tick_test.go", lines[0].File)
+}
+
+func TestTickInterval(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.TickEvery(10*time.Millisecond, 0)
+
+	d.Tick("slow")
+	d.Tick("slow")
+	assert.Empty(t, rec.Lines())
+
+	time.Sleep(20 * time.Millisecond)
+	d.Tick("slow")
+
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Regexp(t, `^tick slow: \d+/s \(3 total\)$`, lines[0].Msg)
+}
+
+func TestTickDefault(t *testing.T) {
+	d := New()
+	d.Writer(NewRecorder())
+	d.Tick("x")
+	assert.Equal(t, defTickInterval, d.tickInterval)
+}
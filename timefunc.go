@@ -0,0 +1,41 @@
+package dabug
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// TimeFunc runs fn n times and writes the total and per run duration and
+// allocations, ie: `parse: 1000 runs in 12ms, 12µs/op, 3 allocs/op, 96 B/op`.
+// Like testing.AllocsPerRun fn is run once beforehand as a warm up and
+// GOMAXPROCS is set to 1 while measuring. fn is not run when d is disabled.
+func TimeFunc(name string, n int, fn func()) {
+	defDabugger.TimeFunc(name, n, fn)
+}
+
+func (d *Dabugger) TimeFunc(name string, n int, fn func()) {
+	if !d.enabled() {
+		return
+	}
+
+	n = max(n, 1)
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(1))
+
+	fn()
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		fn()
+	}
+	took := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	d.appendMsg(fmt.Sprintf("%s: %d %s in %s, %s/op, %d allocs/op, %d B/op",
+		name, n, plural(n, "run", "runs"), fmtDuration(took),
+		fmtDuration(took/time.Duration(n)),
+		(after.Mallocs-before.Mallocs)/uint64(n),
+		(after.TotalAlloc-before.TotalAlloc)/uint64(n)))
+}
@@ -0,0 +1,45 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var timeFuncSink []byte
+
+func TestTimeFunc(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	calls := 0
+	d.TimeFunc("alloc", 100, func() {
+		calls++
+		timeFuncSink = make([]byte, 1024)
+	})
+	d.TimeFunc("noop", 0, func() {})
+
+	assert.Equal(t, 101, calls)
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^alloc: 100 runs in \S+, \S+/op, 1 allocs/op, 1024 B/op$`, lines[0].Msg)
+	assert.Regexp(t, `^noop: 1 run in \S+, \S+/op, 0 allocs/op, 0 B/op$`, lines[1].Msg)
+	assert.Equal(t, "timefunc_test.go", lines[0].File)
+}
+
+func TestTimeFuncDisabled(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	calls := 0
+	d.V(3).TimeFunc("noop", 10, func() { calls++ })
+	d.Mute()
+	d.TimeFunc("noop", 10, func() { calls++ })
+
+	assert.Zero(t, calls)
+	assert.Empty(t, rec.Lines())
+}
@@ -0,0 +1,109 @@
+package dabug
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timer is a named timer started with StartTimer.
+type timer struct {
+	start time.Time
+	laps  []lap
+}
+
+// lap is an intermediate split recorded with Lap.
+type lap struct {
+	label string
+	at    time.Time
+}
+
+// StartTimer starts (or restarts) the timer name.
+func StartTimer(name string) {
+	defDabugger.StartTimer(name)
+}
+
+func (d *Dabugger) StartTimer(name string) {
+	d.timersMutex.Lock()
+	defer d.timersMutex.Unlock()
+
+	if d.timers == nil {
+		d.timers = map[string]*timer{}
+	}
+	d.timers[name] = &timer{start: time.Now()}
+}
+
+// Lap records a split time labeled label in the running timer name, laps
+// are written when the timer is stopped.
+func Lap(name, label string) {
+	defDabugger.Lap(name, label)
+}
+
+func (d *Dabugger) Lap(name, label string) {
+	now := time.Now()
+
+	d.timersMutex.Lock()
+	t, ok := d.timers[name]
+	if ok {
+		t.laps = append(t.laps, lap{label: label, at: now})
+	}
+	d.timersMutex.Unlock()
+
+	if !ok {
+		d.appendLevel(LevelWarn, fmt.Sprintf("timer %s: not started", name))
+	}
+}
+
+// StopTimer stops the timer name and writes a line with the time since it
+// was started, ie: `timer load: 12.3ms`, followed by the split time of each
+// lap and the time since the previous lap.
+func StopTimer(name string) time.Duration {
+	return defDabugger.StopTimer(name)
+}
+
+func (d *Dabugger) StopTimer(name string) time.Duration {
+	now := time.Now()
+
+	d.timersMutex.Lock()
+	t, ok := d.timers[name]
+	delete(d.timers, name)
+	d.timersMutex.Unlock()
+
+	if !ok {
+		d.appendLevel(LevelWarn, fmt.Sprintf("timer %s: not started", name))
+		return 0
+	}
+
+	took := now.Sub(t.start)
+	d.appendMsg(t.String(name, took))
+
+	return took
+}
+
+// Timed starts the timer name and returns a func that stops it, ie:
+//
+//	defer dabug.Timed("load")()
+func Timed(name string) func() {
+	return defDabugger.Timed(name)
+}
+
+func (d *Dabugger) Timed(name string) func() {
+	d.StartTimer(name)
+	return func() {
+		d.StopTimer(name)
+	}
+}
+
+func (t *timer) String(name string, took time.Duration) string {
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("timer %s: %s", name, fmtDuration(took)))
+
+	prev := t.start
+	for _, l := range t.laps {
+		sb.WriteString(fmt.Sprintf("\n%s%s: %s (+%s)", indent, l.label,
+			fmtDuration(l.at.Sub(t.start)), fmtDuration(l.at.Sub(prev))))
+		prev = l.at
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,72 @@
+package dabug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimer(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	d.StartTimer("load")
+	time.Sleep(2 * time.Millisecond)
+	took := d.StopTimer("load")
+	assert.GreaterOrEqual(t, took, 2*time.Millisecond)
+
+	assert.Zero(t, d.StopTimer("load"))
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Regexp(t, `^timer load: \d+(\.\d+)?ms$`, lines[0].Msg)
+	assert.Equal(t, "timer_test.go", lines[0].File)
+	assert.Equal(t, "timer load: not started", lines[1].Msg)
+	assert.Equal(t, LevelWarn, lines[1].Level)
+}
+
+func TestTimed(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	func() {
+		defer d.Timed("fn")()
+	}()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Regexp(t, `^timer fn: `, lines[0].Msg)
+	assert.Equal(t, "timer_test.go", lines[0].File)
+	assert.Empty(t, d.timers)
+}
+
+func TestLap(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	d.Lap("phases", "early")
+	d.StartTimer("phases")
+	d.Lap("phases", "parse")
+	d.Lap("phases", "exec")
+	d.StopTimer("phases")
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "timer phases: not started", lines[0].Msg)
+	assert.Regexp(t, `^timer phases: \S+\n  parse: \S+ \(\+\S+\)\n  exec: \S+ \(\+\S+\)$`, lines[1].Msg)
+}
+
+func TestTimerString(t *testing.T) {
+	start := time.Now()
+	tm := &timer{start: start, laps: []lap{
+		{"a", start.Add(10 * time.Millisecond)},
+		{"b", start.Add(25 * time.Millisecond)},
+	}}
+
+	assert.Equal(t, "timer x: 30ms\n  a: 10ms (+10ms)\n  b: 25ms (+15ms)", tm.String("x", 30*time.Millisecond))
+}
@@ -0,0 +1,59 @@
+package dabug
+
+import (
+	"strings"
+	"time"
+)
+
+// TimingMode selects which durations are included in each line, modes
+// may be combined.
+type TimingMode int
+
+const (
+	// TimingOff disables timing output.
+	TimingOff TimingMode = 0
+	// TimingDelta prints the time since the previous line, ie: `+12.4ms`.
+	TimingDelta TimingMode = 1 << iota
+	// TimingElapsed prints the time since the section started, ie: `@1.2s`.
+	// A section starts with the first line after a Flush.
+	TimingElapsed
+)
+
+// Timing sets which durations are printed with each line.
+func Timing(mode TimingMode) {
+	defDabugger.Timing(mode)
+}
+
+func (d *Dabugger) Timing(mode TimingMode) {
+	d.timing = mode
+}
+
+// sourceStr renders the call site of l followed by the enabled timings and
+// goroutine.
+func sourceStr(l *Line) string {
+	return l.Source.String() + timingStr(l) + goroutineStr(l)
+}
+
+func timingStr(l *Line) string {
+	sb := strings.Builder{}
+	if l.timing&TimingDelta != 0 {
+		sb.WriteString(" +" + fmtDuration(l.Delta))
+	}
+	if l.timing&TimingElapsed != 0 {
+		sb.WriteString(" @" + fmtDuration(l.Elapsed))
+	}
+	return sb.String()
+}
+
+// fmtDuration rounds d to a precision that is readable at a glance.
+func fmtDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		d = d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		d = d.Round(100 * time.Microsecond)
+	case d >= time.Microsecond:
+		d = d.Round(time.Microsecond)
+	}
+	return d.String()
+}
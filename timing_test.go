@@ -0,0 +1,40 @@
+package dabug
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTiming(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.Timing(TimingDelta | TimingElapsed)
+	d.AutoFlush(false)
+	d.Msg("one")
+	time.Sleep(2 * time.Millisecond)
+	d.Msg("two")
+	d.Flush()
+
+	parts := strings.Split(sb.String(), "\n")
+	require.Len(t, parts, 5)
+	assert.Regexp(t, `timing_test.go:\d+ \+0s @0s\s+- one$`, parts[1])
+	assert.Regexp(t, `timing_test.go:\d+ \+[\d.]+ms @[\d.]+ms - two$`, parts[2])
+
+	sb.Reset()
+	d.Timing(TimingOff)
+	d.Msg("three")
+	d.Flush()
+	assert.Regexp(t, `timing_test.go:\d+ - three\n`, sb.String())
+}
+
+func TestFmtDuration(t *testing.T) {
+	assert.Equal(t, "12.4ms", fmtDuration(12*time.Millisecond+432*time.Microsecond))
+	assert.Equal(t, "1.235s", fmtDuration(1234567891))
+	assert.Equal(t, "15µs", fmtDuration(15*time.Microsecond+100))
+	assert.Equal(t, "500ns", fmtDuration(500))
+}
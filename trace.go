@@ -0,0 +1,50 @@
+package dabug
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// Trace writes `-> func` and returns a func that writes `<- func (took 12ms)`,
+// nested traces on the same goroutine are indented, ie:
+//
+//	defer dabug.Trace()()
+func Trace() func() {
+	return defDabugger.Trace()
+}
+
+func (d *Dabugger) Trace() func() {
+	if !d.enabled() {
+		return func() {}
+	}
+
+	src := d.getSource()
+	fn := path.Base(src.Function)
+	id := goid()
+
+	d.timersMutex.Lock()
+	if d.traces == nil {
+		d.traces = map[int64]int{}
+	}
+	depth := d.traces[id]
+	d.traces[id]++
+	d.timersMutex.Unlock()
+
+	pad := strings.Repeat(indent, depth)
+	d.appendLine(&Line{Source: src, Msg: fmt.Sprintf("%s-> %s", pad, fn)})
+	start := time.Now()
+
+	return func() {
+		took := time.Since(start)
+
+		d.timersMutex.Lock()
+		if d.traces[id]--; d.traces[id] <= 0 {
+			delete(d.traces, id)
+		}
+		d.timersMutex.Unlock()
+
+		d.appendMsg(fmt.Sprintf("%s<- %s (took %s)", pad, fn, fmtDuration(took)))
+	}
+}
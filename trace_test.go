@@ -0,0 +1,36 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tracedInner(d *Dabugger) {
+	defer d.Trace()()
+}
+
+func tracedOuter(d *Dabugger) {
+	defer d.Trace()()
+	tracedInner(d)
+}
+
+func TestTrace(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	tracedOuter(d)
+
+	lines := rec.Lines()
+	require.Len(t, lines, 4)
+	assert.Equal(t, "-> dabug.tracedOuter", lines[0].Msg)
+	assert.Equal(t, "  -> dabug.tracedInner", lines[1].Msg)
+	assert.Regexp(t, `^  <- dabug.tracedInner \(took .+\)$`, lines[2].Msg)
+	assert.Regexp(t, `^<- dabug.tracedOuter \(took .+\)$`, lines[3].Msg)
+	for _, l := range lines {
+		assert.Equal(t, "trace_test.go", l.File)
+	}
+	assert.Empty(t, d.traces)
+}
@@ -0,0 +1,81 @@
+package dabug
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TracedOnce is a drop-in sync.Once that writes the stack of the call that
+// runs the function, and a line for every later call with where it was
+// run, to debug initialization order, ie:
+//
+//	once config: first call
+//	  main.loadConfig
+//	    /src/config.go:12
+//	once config: done in 3ms
+//	once config: already done by config.go:12
+type TracedOnce struct {
+	Name string
+	// Dabugger writes the lines, the default Dabugger is used if nil
+	Dabugger *Dabugger
+
+	once sync.Once
+	// mu guards first, the call site that ran the function
+	mu    sync.Mutex
+	first *Source
+}
+
+func (o *TracedOnce) dabugger() *Dabugger {
+	if o.Dabugger != nil {
+		return o.Dabugger
+	}
+	return defDabugger
+}
+
+func (o *TracedOnce) Do(f func()) {
+	d := o.dabugger()
+	if !d.enabled() {
+		o.once.Do(f)
+		return
+	}
+
+	src := d.getSource()
+	ran := false
+	start := time.Now()
+	o.once.Do(func() {
+		ran = true
+		o.mu.Lock()
+		o.first = &src
+		o.mu.Unlock()
+
+		// sync.Once is not part of this package, so the source is the
+		// captured call site instead of the frame calling the closure
+		stack := callerStack()
+		for strings.HasPrefix(stack, indent+"sync.") {
+			// drop the sync.Once frames calling the closure
+			_, stack, _ = strings.Cut(stack, "\n")
+			_, stack, _ = strings.Cut(stack, "\n")
+		}
+		d.appendLine(&Line{Source: src, Msg: fmt.Sprintf("once %s: first call\n%s", o.Name, stack)})
+		f()
+		d.appendLine(&Line{Source: src, Msg: fmt.Sprintf("once %s: done in %s", o.Name, fmtDuration(time.Since(start)))})
+	})
+	if ran {
+		return
+	}
+
+	o.mu.Lock()
+	first := o.first
+	o.mu.Unlock()
+
+	msg := fmt.Sprintf("once %s: already done", o.Name)
+	if first != nil {
+		msg += " by " + first.String()
+	}
+	if waited := time.Since(start); waited >= time.Millisecond {
+		msg += fmt.Sprintf(" (waited %s)", fmtDuration(waited))
+	}
+	d.appendMsg(msg)
+}
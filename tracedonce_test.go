@@ -0,0 +1,35 @@
+package dabug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracedOnce(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	calls := 0
+	o := &TracedOnce{Name: "config", Dabugger: d}
+	load := func() { o.Do(func() { calls++ }) }
+	load()
+	load()
+	o.Do(func() { calls++ })
+
+	assert.Equal(t, 1, calls)
+	lines := rec.Lines()
+	require.Len(t, lines, 4)
+
+	first := strings.Split(lines[0].Msg, "\n")
+	assert.Equal(t, "once config: first call", first[0])
+	assert.Equal(t, "  github.com/dcaravel/dabug.TestTracedOnce.func1", first[1])
+	assert.Contains(t, first[2], "tracedonce_test.go:")
+	assert.Regexp(t, `^once config: done in \S+$`, lines[1].Msg)
+	assert.Equal(t, "once config: already done by "+lines[0].Source.String(), lines[2].Msg)
+	assert.Equal(t, "once config: already done by "+lines[0].Source.String(), lines[3].Msg)
+	assert.NotEqual(t, lines[0].Source, lines[3].Source)
+}
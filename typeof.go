@@ -0,0 +1,38 @@
+package dabug
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// TypeOf appends a line with the dynamic type of v, and the address of
+// pointer like values, ie: `type: (*dabug.Foo)(0xc000010000)`. A nil pointer
+// stored in v is called out as a typed nil since v != nil even though the
+// pointer is, ie: `type: (*dabug.Foo)(nil), typed nil in non-nil interface`.
+func TypeOf(v any) {
+	defDabugger.TypeOf(v)
+}
+
+func (d *Dabugger) TypeOf(v any) {
+	if !d.enabled() {
+		return
+	}
+
+	d.appendMsg("type: " + typeInfo(reflect.ValueOf(v)))
+}
+
+func typeInfo(v reflect.Value) string {
+	if !v.IsValid() {
+		return "<nil>, nil interface"
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.Slice, reflect.UnsafePointer:
+		if v.IsNil() {
+			return fmt.Sprintf("(%s)(nil), typed nil in non-nil interface", v.Type())
+		}
+		return fmt.Sprintf("(%s)(%#x)", v.Type(), v.Pointer())
+	}
+
+	return fmt.Sprintf("%s, kind %s", v.Type(), v.Kind())
+}
@@ -0,0 +1,46 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type typedErr struct{}
+
+func (*typedErr) Error() string { return "typed" }
+
+func TestTypeOf(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	var nilErr *typedErr
+	var err error = nilErr
+
+	d.TypeOf(nil)
+	d.TypeOf(err)
+	d.TypeOf(&typedErr{})
+	d.TypeOf(12)
+	d.TypeOf(typedErr{})
+
+	lines := rec.Lines()
+	require.Len(t, lines, 5)
+	assert.Equal(t, "type: <nil>, nil interface", lines[0].Msg)
+	assert.Equal(t, "type: (*dabug.typedErr)(nil), typed nil in non-nil interface", lines[1].Msg)
+	assert.Regexp(t, `^type: \(\*dabug.typedErr\)\(0x[0-9a-f]+\)$`, lines[2].Msg)
+	assert.Equal(t, "type: int, kind int", lines[3].Msg)
+	assert.Equal(t, "type: dabug.typedErr, kind struct", lines[4].Msg)
+}
+
+func TestPrettyTypedNil(t *testing.T) {
+	type holder struct {
+		Err error
+		Nil error
+	}
+
+	var nilErr *typedErr
+	d := New()
+	assert.Equal(t, "dabug.holder{Err:error((*dabug.typedErr)(nil)), Nil:error(nil)}", d.prettyStr(holder{Err: nilErr}))
+}
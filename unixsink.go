@@ -0,0 +1,127 @@
+package dabug
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// unixWriteTimeout bounds how long a slow reader can stall a write.
+	unixWriteTimeout = time.Second
+	// unixMinAcceptDelay and unixMaxAcceptDelay bound the backoff after
+	// failed accepts, ie: when out of file descriptors.
+	unixMinAcceptDelay = 5 * time.Millisecond
+	unixMaxAcceptDelay = time.Second
+)
+
+// UnixWriter serves output over a unix domain socket to any number of
+// connected readers, see Listen. Output written while no reader is
+// connected is dropped.
+type UnixWriter struct {
+	ln net.Listener
+
+	mu      sync.Mutex
+	clients map[net.Conn]struct{}
+	// closed is set by Close so that late accepted readers are dropped
+	closed bool
+}
+
+// UnixSink listens on the unix socket at path, an existing socket file at
+// path is replaced. Use it with Writer or AddWriter.
+func UnixSink(path string) (*UnixWriter, error) {
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&fs.ModeSocket != 0 {
+		os.Remove(path)
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &UnixWriter{
+		ln:      ln,
+		clients: map[net.Conn]struct{}{},
+	}
+	go u.accept()
+
+	return u, nil
+}
+
+// Listen connects to a socket served by UnixSink and returns the stream of
+// output, ie:
+//
+//	r, err := dabug.Listen("/tmp/dabug.sock")
+//	...
+//	io.Copy(os.Stdout, r)
+func Listen(path string) (io.ReadCloser, error) {
+	return net.Dial("unix", path)
+}
+
+func (u *UnixWriter) accept() {
+	var delay time.Duration
+	for {
+		conn, err := u.ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			delay = min(max(delay*2, unixMinAcceptDelay), unixMaxAcceptDelay)
+			time.Sleep(delay)
+			continue
+		}
+		delay = 0
+
+		u.mu.Lock()
+		if u.closed {
+			u.mu.Unlock()
+			conn.Close()
+			return
+		}
+		u.clients[conn] = struct{}{}
+		u.mu.Unlock()
+	}
+}
+
+func (u *UnixWriter) Write(p []byte) (int, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for conn := range u.clients {
+		conn.SetWriteDeadline(time.Now().Add(unixWriteTimeout))
+		if _, err := conn.Write(p); err != nil {
+			conn.Close()
+			delete(u.clients, conn)
+		}
+	}
+
+	return len(p), nil
+}
+
+// Close stops listening and disconnects all readers.
+func (u *UnixWriter) Close() error {
+	err := u.ln.Close()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.closed = true
+	for conn := range u.clients {
+		conn.Close()
+		delete(u.clients, conn)
+	}
+
+	return err
+}
+
+// numClients returns the number of connected readers.
+func (u *UnixWriter) numClients() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return len(u.clients)
+}
@@ -0,0 +1,101 @@
+package dabug
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnixSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dabug.sock")
+
+	u, err := UnixSink(path)
+	require.NoError(t, err)
+
+	r, err := Listen(path)
+	require.NoError(t, err)
+	defer r.Close()
+
+	require.Eventually(t, func() bool { return u.numClients() == 1 }, 5*time.Second, time.Millisecond)
+
+	d := New()
+	d.Writer(u)
+	d.Msg("tail me")
+
+	line, err := bufio.NewReader(r).ReadString('\n')
+	require.NoError(t, err)
+	assert.Contains(t, line, "tail me")
+
+	require.NoError(t, u.Close())
+	_, err = io.ReadAll(r)
+	assert.NoError(t, err)
+
+	// a stale socket file is replaced
+	u, err = UnixSink(path)
+	require.NoError(t, err)
+	u.Close()
+}
+
+// acceptListener is a net.Listener whose Accept returns conns from a
+// channel or err once the channel is empty.
+type acceptListener struct {
+	net.Listener
+	conns   chan net.Conn
+	err     error
+	accepts atomic.Int32
+}
+
+func (l *acceptListener) Accept() (net.Conn, error) {
+	l.accepts.Add(1)
+	select {
+	case conn, ok := <-l.conns:
+		if !ok {
+			return nil, net.ErrClosed
+		}
+		return conn, nil
+	default:
+		return nil, l.err
+	}
+}
+
+func TestUnixSinkAcceptBackoff(t *testing.T) {
+	ln := &acceptListener{conns: make(chan net.Conn), err: errors.New("accept: too many open files")}
+	u := &UnixWriter{ln: ln, clients: map[net.Conn]struct{}{}}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		u.accept()
+	}()
+	time.Sleep(50 * time.Millisecond)
+	close(ln.conns)
+	<-done
+
+	// 5, 10, and 20ms delays fit in 50ms where a busy loop spins
+	assert.LessOrEqual(t, ln.accepts.Load(), int32(6))
+}
+
+func TestUnixSinkAcceptAfterClose(t *testing.T) {
+	ln := &acceptListener{conns: make(chan net.Conn, 1)}
+	u := &UnixWriter{ln: ln, clients: map[net.Conn]struct{}{}}
+
+	u.mu.Lock()
+	u.closed = true
+	u.mu.Unlock()
+
+	client, server := net.Pipe()
+	ln.conns <- server
+	u.accept()
+
+	_, err := client.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Zero(t, u.numClients())
+}
@@ -0,0 +1,52 @@
+package dabug
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Var appends a line with v rendered as `name = value`.
+func Var(name string, v any) {
+	defDabugger.Var(name, v)
+}
+
+func (d *Dabugger) Var(name string, v any) {
+	if !d.enabled() {
+		return
+	}
+
+	d.appendMsg(fmt.Sprintf("%s = %s", name, d.varStr(name, v)))
+}
+
+// Vars appends a line with alternating names and values rendered as
+// `a = 1, b = 2`, ie:
+//
+//	dabug.Vars("a", a, "b", b)
+func Vars(pairs ...any) {
+	defDabugger.Vars(pairs...)
+}
+
+func (d *Dabugger) Vars(pairs ...any) {
+	if !d.enabled() {
+		return
+	}
+
+	var msgs []string
+	for i := 0; i < len(pairs); i += 2 {
+		value := "<missing>"
+		if i+1 < len(pairs) {
+			value = d.varStr(fmt.Sprint(pairs[i]), pairs[i+1])
+		}
+		msgs = append(msgs, fmt.Sprintf("%v = %s", pairs[i], value))
+	}
+	d.appendMsg(strings.Join(msgs, ", "))
+}
+
+// varStr renders the value v of the variable name, redacted names are
+// rendered as `***`.
+func (d *Dabugger) varStr(name string, v any) string {
+	if d.redacted(name) {
+		return redacted
+	}
+	return d.objStr(d.redactValue(name, v))
+}
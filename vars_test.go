@@ -0,0 +1,37 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVar(t *testing.T) {
+	d, sb := newMsgDabugger()
+	d.Var("count", 3)
+	d.Var("names", []string{"a", "b"})
+	d.Vars("x", 1, "y", "two", "z")
+	d.Vars()
+
+	assert.Equal(t, "count = 3\n"+
+		`names = []string{"a", "b"}`+"\n"+
+		`x = 1, y = "two", z = <missing>`+"\n"+
+		"\n", sb.String())
+}
+
+func TestVarRedact(t *testing.T) {
+	type creds struct {
+		User     string
+		Password string `dabug:"redact"`
+	}
+
+	d, sb := newMsgDabugger()
+	d.Redact("token")
+	d.Var("token", "tok-VAR")
+	d.Var("c", creds{"dave", "hunter2"})
+	d.Vars("Token", "tok-VARS", "n", 1)
+
+	assert.Equal(t, "token = ***\n"+
+		`c = dabug.creds{User:"dave", Password:***}`+"\n"+
+		"Token = ***, n = 1\n", sb.String())
+}
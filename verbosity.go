@@ -0,0 +1,35 @@
+package dabug
+
+// Verbosity sets the highest level enabled by V, the default is 0.
+func Verbosity(n int) {
+	defDabugger.Verbosity(n)
+}
+
+func (d *Dabugger) Verbosity(n int) {
+	d.linesMutex.Lock()
+	defer d.linesMutex.Unlock()
+
+	d.verbosity = n
+}
+
+// V returns a Dabugger that writes only if the verbosity is at least n, so
+// detailed instrumentation costs nothing until enabled, ie:
+//
+//	dabug.V(3).Msg("state %v", s)
+func V(n int) *Dabugger {
+	return defDabugger.V(n)
+}
+
+func (d *Dabugger) V(n int) *Dabugger {
+	d.linesMutex.Lock()
+	defer d.linesMutex.Unlock()
+
+	if n <= d.verbosity {
+		return d
+	}
+
+	if d.quiet == nil {
+		d.quiet = &Dabugger{state: d.state, disabled: true}
+	}
+	return d.quiet
+}
@@ -0,0 +1,63 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestV(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	calls := 0
+	expensive := func() string {
+		calls++
+		return "detail"
+	}
+
+	d.V(0).Msg("level 0")
+	d.V(1).Msg("level 1")
+	d.V(2).MsgFunc(expensive)
+	d.V(3).Objs(1)
+	assert.Zero(t, calls)
+	assert.Same(t, d.V(1), d.V(5))
+
+	d.Verbosity(2)
+	assert.Same(t, d, d.V(2))
+	d.V(1).Msg("level 1")
+	d.V(2).MsgFunc(expensive)
+	d.V(3).Warn("level 3")
+
+	lines := rec.Lines()
+	require.Len(t, lines, 3)
+	assert.Equal(t, "level 0", lines[0].Msg)
+	assert.Equal(t, "level 1", lines[1].Msg)
+	assert.Equal(t, "detail", lines[2].Msg)
+	assert.Equal(t, "verbosity_test.go", lines[2].File)
+	assert.Equal(t, 1, calls)
+}
+
+func TestVSkipsDumps(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	calls := 0
+	d.SetRedactor(func(string, any) (any, bool) {
+		calls++
+		return nil, false
+	})
+
+	assert.Zero(t, callDumps(d.V(3)))
+	d.Flush()
+	assert.Zero(t, calls, "dumps above the verbosity must not render their values")
+	assert.Nil(t, d.memStats)
+	assert.Nil(t, d.goroutines)
+	assert.Nil(t, d.ticks)
+	assert.Empty(t, rec.Lines())
+
+	assert.NotZero(t, callDumps(d))
+	assert.NotZero(t, calls)
+}
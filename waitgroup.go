@@ -0,0 +1,146 @@
+package dabug
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WaitGroup is a drop-in sync.WaitGroup that records the call sites of Add
+// and Done, so the missing Done calls can be found. Outstanding writes the
+// counts per call site, Wait writes them once when it blocks longer than
+// Timeout, ie:
+//
+//	waitgroup workers: 1 outstanding, added 3 at main.go:20, done 2 at worker.go:31
+type WaitGroup struct {
+	Name string
+	// Timeout is how long Wait blocks before writing the outstanding
+	// counts, 0 never writes them
+	Timeout time.Duration
+	// Dabugger writes the lines, the default Dabugger is used if nil
+	Dabugger *Dabugger
+
+	wg sync.WaitGroup
+	// mu guards the counts per call site
+	mu    sync.Mutex
+	adds  map[Source]int
+	dones map[Source]int
+}
+
+func (w *WaitGroup) dabugger() *Dabugger {
+	if w.Dabugger != nil {
+		return w.Dabugger
+	}
+	return defDabugger
+}
+
+func (w *WaitGroup) Add(delta int) {
+	w.record(delta)
+	w.wg.Add(delta)
+}
+
+func (w *WaitGroup) Done() {
+	w.record(-1)
+	w.wg.Done()
+}
+
+func (w *WaitGroup) Wait() {
+	if w.Timeout <= 0 {
+		w.wg.Wait()
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(w.Timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return
+	case <-timer.C:
+	}
+
+	d := w.dabugger()
+	if d.enabled() {
+		d.appendMsg(w.outstandingStr() + fmt.Sprintf(" (Wait blocked %s)", fmtDuration(w.Timeout)))
+	}
+	<-done
+}
+
+// Outstanding writes the number of outstanding Add calls and the Add and
+// Done counts per call site.
+func (w *WaitGroup) Outstanding() {
+	if d := w.dabugger(); d.enabled() {
+		d.appendMsg(w.outstandingStr())
+	}
+}
+
+func (w *WaitGroup) record(delta int) {
+	d := w.dabugger()
+	if !d.enabled() {
+		return
+	}
+	src := d.getSource()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.adds == nil {
+		w.adds, w.dones = map[Source]int{}, map[Source]int{}
+	}
+	if delta >= 0 {
+		w.adds[src] += delta
+	} else {
+		w.dones[src] -= delta
+	}
+}
+
+func (w *WaitGroup) outstandingStr() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	n := 0
+	for _, c := range w.adds {
+		n += c
+	}
+	for _, c := range w.dones {
+		n -= c
+	}
+
+	msg := fmt.Sprintf("waitgroup %s: %d outstanding", w.Name, n)
+	if s := siteCountsStr("added", w.adds); s != "" {
+		msg += ", " + s
+	}
+	if s := siteCountsStr("done", w.dones); s != "" {
+		msg += ", " + s
+	}
+	return msg
+}
+
+// siteCountsStr renders counts per call site in call site order, ie:
+// `added 3 at main.go:20, added 1 at main.go:31`.
+func siteCountsStr(verb string, counts map[Source]int) string {
+	sites := make([]Source, 0, len(counts))
+	for s := range counts {
+		sites = append(sites, s)
+	}
+	slices.SortFunc(sites, func(a, b Source) int {
+		if c := cmp.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Line, b.Line)
+	})
+
+	var parts []string
+	for _, s := range sites {
+		parts = append(parts, fmt.Sprintf("%s %d at %s", verb, counts[s], s))
+	}
+	return strings.Join(parts, ", ")
+}
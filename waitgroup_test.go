@@ -0,0 +1,51 @@
+package dabug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitGroup(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	wg := &WaitGroup{Name: "workers", Dabugger: d}
+	wg.Outstanding()
+	wg.Add(2)
+	wg.Add(1)
+	for i := 0; i < 2; i++ {
+		go func() { defer wg.Done() }()
+	}
+	time.Sleep(10 * time.Millisecond)
+	wg.Outstanding()
+	wg.Done()
+	wg.Wait()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "waitgroup workers: 0 outstanding", lines[0].Msg)
+	assert.Regexp(t, `^waitgroup workers: 1 outstanding, added 2 at waitgroup_test.go:\d+, added 1 at waitgroup_test.go:\d+, done 2 at waitgroup_test.go:\d+$`, lines[1].Msg)
+}
+
+func TestWaitGroupTimeout(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	wg := &WaitGroup{Name: "slow", Timeout: 10 * time.Millisecond, Dabugger: d}
+	wg.Add(1)
+	go func() {
+		time.Sleep(40 * time.Millisecond)
+		wg.Done()
+	}()
+	wg.Wait()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Regexp(t, `^waitgroup slow: 1 outstanding, added 1 at waitgroup_test.go:\d+ \(Wait blocked 10ms\)$`, lines[0].Msg)
+	assert.Equal(t, "waitgroup_test.go", lines[0].File)
+}
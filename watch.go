@@ -0,0 +1,68 @@
+package dabug
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// watch is a value registered with Watch.
+type watch struct {
+	name string
+	ptr  any
+}
+
+// Watch registers ptr so that the value it points to is written as a line,
+// ie: `watch depth = 12`, at every Flush. Watching an existing name replaces
+// it, watches registered while disabled, ie: with V, are ignored.
+func Watch(name string, ptr any) {
+	defDabugger.Watch(name, ptr)
+}
+
+func (d *Dabugger) Watch(name string, ptr any) {
+	if !d.enabled() {
+		return
+	}
+
+	d.statsMutex.Lock()
+	defer d.statsMutex.Unlock()
+
+	d.watches = slices.DeleteFunc(d.watches, func(w watch) bool { return w.name == name })
+	d.watches = append(d.watches, watch{name: name, ptr: ptr})
+}
+
+// Unwatch removes the watch name.
+func Unwatch(name string) {
+	defDabugger.Unwatch(name)
+}
+
+func (d *Dabugger) Unwatch(name string) {
+	d.statsMutex.Lock()
+	defer d.statsMutex.Unlock()
+
+	d.watches = slices.DeleteFunc(d.watches, func(w watch) bool { return w.name == name })
+}
+
+// writeWatches appends a line with the current value of every watch.
+func (d *Dabugger) writeWatches() {
+	if !d.enabled() {
+		return
+	}
+
+	d.statsMutex.Lock()
+	watches := slices.Clone(d.watches)
+	d.statsMutex.Unlock()
+
+	for _, w := range watches {
+		v := reflect.ValueOf(w.ptr)
+		if v.Kind() == reflect.Pointer && !v.IsNil() {
+			v = v.Elem()
+		}
+
+		var val any
+		if v.IsValid() {
+			val = v.Interface()
+		}
+		d.appendMsg(fmt.Sprintf("watch %s = %s", w.name, d.objStr(d.redactValue(w.name, val))))
+	}
+}
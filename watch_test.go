@@ -0,0 +1,35 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	d, sb := newMsgDabugger()
+	d.AutoFlush(false)
+
+	depth := 1
+	items := []string{"a"}
+	d.Watch("depth", &depth)
+	d.Watch("items", &items)
+	d.Msg("first")
+	d.Flush()
+
+	depth = 5
+	items = append(items, "b")
+	d.Watch("depth", &depth)
+	d.Flush()
+
+	d.Unwatch("items")
+	d.Unwatch("missing")
+	d.Flush()
+
+	assert.Equal(t, "first\n"+
+		"watch depth = 1\n"+
+		`watch items = []string{"a"}`+"\n"+
+		`watch items = []string{"a", "b"}`+"\n"+
+		"watch depth = 5\n"+
+		"watch depth = 5\n", sb.String())
+}
@@ -0,0 +1,62 @@
+package dabug
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// defWatchInterval is the WatchChanges interval used for intervals <= 0.
+const defWatchInterval = time.Second
+
+// WatchChanges polls getter every interval in the background and writes a
+// line with the old and new value whenever the result changes, ie:
+// `watch state changed: "idle" -> "busy"`. Lines are attributed to the
+// WatchChanges call, the returned func stops polling. An interval <= 0
+// polls every second.
+func WatchChanges(name string, getter func() any, interval time.Duration) func() {
+	return defDabugger.WatchChanges(name, getter, interval)
+}
+
+func (d *Dabugger) WatchChanges(name string, getter func() any, interval time.Duration) func() {
+	src := d.getSource()
+	prev := getter()
+	if interval <= 0 {
+		interval = defWatchInterval
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			cur := getter()
+			if reflect.DeepEqual(prev, cur) {
+				continue
+			}
+			d.appendLine(&Line{
+				Source: src,
+				Msg: fmt.Sprintf("watch %s changed: %s -> %s", name,
+					d.objStr(d.redactValue(name, prev)), d.objStr(d.redactValue(name, cur))),
+			})
+			prev = cur
+		}
+	}()
+
+	once := sync.Once{}
+	return func() {
+		once.Do(func() { close(done) })
+		<-stopped
+	}
+}
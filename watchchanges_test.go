@@ -0,0 +1,55 @@
+package dabug
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchChanges(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	var state atomic.Value
+	state.Store("idle")
+	stop := d.WatchChanges("state", func() any { return state.Load() }, time.Millisecond)
+
+	time.Sleep(10 * time.Millisecond)
+	state.Store("busy")
+	require.Eventually(t, func() bool { return len(rec.Lines()) == 1 }, time.Second, time.Millisecond)
+
+	state.Store("idle")
+	require.Eventually(t, func() bool { return len(rec.Lines()) == 2 }, time.Second, time.Millisecond)
+
+	stop()
+	stop()
+	state.Store("done")
+	time.Sleep(10 * time.Millisecond)
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, `watch state changed: "idle" -> "busy"`, lines[0].Msg)
+	assert.Equal(t, `watch state changed: "busy" -> "idle"`, lines[1].Msg)
+	assert.Equal(t, "watchchanges_test.go", lines[0].File)
+	assert.Equal(t, "github.com/dcaravel/dabug.TestWatchChanges", lines[0].Function)
+}
+
+func TestWatchChangesDefaultInterval(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	var state atomic.Value
+	state.Store("idle")
+	for _, interval := range []time.Duration{0, -time.Second} {
+		stop := d.WatchChanges("state", func() any { return state.Load() }, interval)
+		defer stop()
+	}
+
+	state.Store("busy")
+	require.Eventually(t, func() bool { return len(rec.Lines()) == 2 }, 3*defWatchInterval, 10*time.Millisecond)
+}
@@ -0,0 +1,37 @@
+package dabug
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Watchdog writes a warning with the stacks of all goroutines and flushes
+// if the returned func is not called within timeout, to catch hangs without
+// attaching a debugger, ie:
+//
+//	defer dabug.Watchdog("shutdown", 5*time.Second)()
+func Watchdog(name string, timeout time.Duration) func() {
+	return defDabugger.Watchdog(name, timeout)
+}
+
+func (d *Dabugger) Watchdog(name string, timeout time.Duration) func() {
+	if !d.enabled() {
+		return func() {}
+	}
+
+	src := d.getSource()
+	t := time.AfterFunc(timeout, func() {
+		stacks := strings.TrimSpace(allStacks())
+		d.appendLine(&Line{
+			Source: src,
+			Level:  LevelWarn,
+			Msg:    fmt.Sprintf("watchdog %s: not done after %s\n%s", name, fmtDuration(timeout), stacks),
+		})
+		d.Flush()
+	})
+
+	return func() {
+		t.Stop()
+	}
+}
@@ -0,0 +1,29 @@
+package dabug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchdog(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.AutoFlush(false)
+
+	d.Watchdog("fast", 20*time.Millisecond)()
+
+	stop := d.Watchdog("hang", 10*time.Millisecond)
+	time.Sleep(40 * time.Millisecond)
+	stop()
+
+	lines := rec.Lines()
+	require.Len(t, lines, 1)
+	assert.Equal(t, LevelWarn, lines[0].Level)
+	assert.Equal(t, "watchdog_test.go", lines[0].File)
+	assert.Regexp(t, `^watchdog hang: not done after 10ms\ngoroutine \d+ \[`, lines[0].Msg)
+	assert.Contains(t, lines[0].Msg, "dabug.TestWatchdog")
+}
@@ -0,0 +1,290 @@
+package dabug
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	wsGUID         = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+	wsWriteTimeout = time.Second
+
+	// wsMaxClientPayload is the largest client data frame that is read,
+	// clients are not expected to send data so larger frames are discarded
+	wsMaxClientPayload = 4 << 10
+	// wsMaxControlPayload is the largest control frame, RFC 6455 5.5
+	wsMaxControlPayload = 125
+
+	wsOpText  = 0x1
+	wsOpClose = 0x8
+	wsOpPing  = 0x9
+	wsOpPong  = 0xa
+)
+
+// WebSocketWriter serves output to browsers over a WebSocket, each line is
+// sent as a text message. New subscribers are first sent the most recent
+// lines. Requests that are not WebSocket upgrades are served a minimal page
+// that displays the stream.
+type WebSocketWriter struct {
+	backlog int
+
+	mu      sync.Mutex
+	recent  []string
+	clients map[*wsConn]struct{}
+}
+
+type wsConn struct {
+	conn net.Conn
+	mu   sync.Mutex
+}
+
+// WebSocketSink creates a writer that replays up to backlog lines to new
+// subscribers, mount it on an http.ServeMux and use it with Writer or
+// AddWriter.
+func WebSocketSink(backlog int) *WebSocketWriter {
+	return &WebSocketWriter{
+		backlog: max(backlog, 0),
+		clients: map[*wsConn]struct{}{},
+	}
+}
+
+func (ws *WebSocketWriter) Write(p []byte) (int, error) {
+	lines := strings.Split(strings.TrimSuffix(string(p), "\n"), "\n")
+
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	ws.recent = append(ws.recent, lines...)
+	if over := len(ws.recent) - ws.backlog; over > 0 {
+		ws.recent = ws.recent[over:]
+	}
+
+	for c := range ws.clients {
+		for _, l := range lines {
+			if err := c.writeFrame(wsOpText, []byte(l)); err != nil {
+				c.conn.Close()
+				delete(ws.clients, c)
+				break
+			}
+		}
+	}
+
+	return len(p), nil
+}
+
+func (ws *WebSocketWriter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, wsPage)
+		return
+	}
+
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		http.Error(w, "missing Sec-WebSocket-Key", http.StatusBadRequest)
+		return
+	}
+
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "websocket not supported", http.StatusInternalServerError)
+		return
+	}
+
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		return
+	}
+
+	sum := sha1.Sum([]byte(key + wsGUID))
+	fmt.Fprintf(brw, "HTTP/1.1 101 Switching Protocols\r\n"+
+		"Upgrade: websocket\r\n"+
+		"Connection: Upgrade\r\n"+
+		"Sec-WebSocket-Accept: %s\r\n\r\n", base64.StdEncoding.EncodeToString(sum[:]))
+	if err := brw.Flush(); err != nil {
+		conn.Close()
+		return
+	}
+
+	c := &wsConn{conn: conn}
+
+	ws.mu.Lock()
+	for _, l := range ws.recent {
+		if err := c.writeFrame(wsOpText, []byte(l)); err != nil {
+			ws.mu.Unlock()
+			conn.Close()
+			return
+		}
+	}
+	ws.clients[c] = struct{}{}
+	ws.mu.Unlock()
+
+	ws.read(c, brw.Reader)
+}
+
+// Close disconnects all subscribers.
+func (ws *WebSocketWriter) Close() error {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+
+	for c := range ws.clients {
+		c.writeFrame(wsOpClose, nil)
+		c.conn.Close()
+		delete(ws.clients, c)
+	}
+
+	return nil
+}
+
+// read handles control frames from the client until it disconnects, data
+// frames are ignored.
+func (ws *WebSocketWriter) read(c *wsConn, r *bufio.Reader) {
+	defer func() {
+		ws.mu.Lock()
+		delete(ws.clients, c)
+		ws.mu.Unlock()
+		c.conn.Close()
+	}()
+
+	for {
+		op, payload, err := readFrame(r, wsMaxClientPayload)
+		if err != nil {
+			return
+		}
+
+		switch op {
+		case wsOpClose:
+			c.writeFrame(wsOpClose, nil)
+			return
+		case wsOpPing:
+			if err := c.writeFrame(wsOpPong, payload); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeFrame writes a single unmasked, unfragmented frame.
+func (c *wsConn) writeFrame(op byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	header := []byte{0x80 | op}
+	switch n := len(payload); {
+	case n < 126:
+		header = append(header, byte(n))
+	case n <= 0xffff:
+		header = append(header, 126)
+		header = binary.BigEndian.AppendUint16(header, uint16(n))
+	default:
+		header = append(header, 127)
+		header = binary.BigEndian.AppendUint64(header, uint64(n))
+	}
+
+	c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// errWSProtocol is returned by readFrame for frames violating RFC 6455.
+var errWSProtocol = errors.New("websocket protocol error")
+
+// readFrame reads a single frame, unmasking the payload. The payload of data
+// frames larger than maxPayload is discarded instead of read into memory,
+// frames with reserved bits set and oversized or fragmented control frames
+// are rejected.
+func readFrame(r io.Reader, maxPayload int) (byte, []byte, error) {
+	var h [2]byte
+	if _, err := io.ReadFull(r, h[:]); err != nil {
+		return 0, nil, err
+	}
+
+	fin := h[0]&0x80 != 0
+	op := h[0] & 0x0f
+	masked := h[1]&0x80 != 0
+	n := uint64(h[1] & 0x7f)
+
+	if h[0]&0x70 != 0 {
+		return 0, nil, fmt.Errorf("%w: reserved bits set", errWSProtocol)
+	}
+	control := op&0x8 != 0
+	if control && (n > wsMaxControlPayload || !fin) {
+		return 0, nil, fmt.Errorf("%w: invalid control frame", errWSProtocol)
+	}
+
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(r, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(r, mask[:]); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	if n > math.MaxInt64 {
+		return 0, nil, fmt.Errorf("%w: invalid payload length", errWSProtocol)
+	}
+	if n > uint64(maxPayload) {
+		if _, err := io.CopyN(io.Discard, r, int64(n)); err != nil {
+			return 0, nil, err
+		}
+		return op, nil, nil
+	}
+
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+
+	return op, payload, nil
+}
+
+const wsPage = `<!DOCTYPE html>
+<html>
+<head><title>dabug</title></head>
+<body style="background:#111;color:#ddd">
+<pre id="out"></pre>
+<script>
+const out = document.getElementById("out");
+const url = location.href.replace(/^http/, "ws");
+const ws = new WebSocket(url);
+ws.onmessage = (e) => {
+	out.textContent += e.data + "\n";
+	window.scrollTo(0, document.body.scrollHeight);
+};
+</script>
+</body>
+</html>
+`
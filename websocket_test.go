@@ -0,0 +1,128 @@
+package dabug
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dialWebSocket performs a minimal client handshake against srv.
+func dialWebSocket(t *testing.T, srv *httptest.Server) (net.Conn, *bufio.Reader) {
+	conn, err := net.Dial("tcp", strings.TrimPrefix(srv.URL, "http://"))
+	require.NoError(t, err)
+
+	_, err = conn.Write([]byte("GET / HTTP/1.1\r\n" +
+		"Host: dabug\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==\r\n" +
+		"Sec-WebSocket-Version: 13\r\n\r\n"))
+	require.NoError(t, err)
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, nil)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	require.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", resp.Header.Get("Sec-WebSocket-Accept"))
+
+	return conn, br
+}
+
+func TestWebSocketSink(t *testing.T) {
+	ws := WebSocketSink(2)
+	srv := httptest.NewServer(ws)
+	defer srv.Close()
+	defer ws.Close()
+
+	d := New()
+	d.Writer(ws)
+	d.LinePrefix("")
+	d.Msg("one")
+	d.Msg("two")
+	d.Msg("three")
+
+	conn, br := dialWebSocket(t, srv)
+	defer conn.Close()
+
+	readText := func() string {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		op, payload, err := readFrame(br, 1<<20)
+		require.NoError(t, err)
+		require.Equal(t, byte(wsOpText), op)
+		return string(payload)
+	}
+
+	// replayed backlog
+	assert.Contains(t, readText(), "two")
+	assert.Contains(t, readText(), "three")
+
+	// live lines, including large frames
+	require.Eventually(t, func() bool {
+		ws.mu.Lock()
+		defer ws.mu.Unlock()
+		return len(ws.clients) == 1
+	}, 5*time.Second, time.Millisecond)
+
+	d.Msg("four")
+	assert.Contains(t, readText(), "four")
+	big := strings.Repeat("x", 70000)
+	d.Msg("%s", big)
+	assert.Contains(t, readText(), big)
+
+	// ping from the client is answered with a masked payload echoed back
+	_, err := conn.Write([]byte{0x80 | wsOpPing, 0x80 | 2, 1, 2, 3, 4, 'h' ^ 1, 'i' ^ 2})
+	require.NoError(t, err)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	op, payload, err := readFrame(br, 1<<20)
+	require.NoError(t, err)
+	assert.Equal(t, byte(wsOpPong), op)
+	assert.Equal(t, "hi", string(payload))
+}
+
+func TestWebSocketPage(t *testing.T) {
+	ws := WebSocketSink(0)
+	rec := httptest.NewRecorder()
+	ws.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Contains(t, rec.Body.String(), "new WebSocket")
+}
+
+func TestReadFrameLimits(t *testing.T) {
+	frame := func(h0, h1 byte, ext ...byte) *bytes.Reader {
+		return bytes.NewReader(append([]byte{h0, h1}, ext...))
+	}
+
+	// reserved bits
+	_, _, err := readFrame(frame(0x80|0x40|wsOpText, 0), 10)
+	assert.ErrorIs(t, err, errWSProtocol)
+
+	// oversized and fragmented control frames
+	_, _, err = readFrame(frame(0x80|wsOpPing, 126, 0, 200), 1000)
+	assert.ErrorIs(t, err, errWSProtocol)
+	_, _, err = readFrame(frame(wsOpPing, 0), 10)
+	assert.ErrorIs(t, err, errWSProtocol)
+
+	// huge claimed lengths are not allocated
+	_, _, err = readFrame(frame(0x80|wsOpText, 127, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff), 10)
+	assert.ErrorIs(t, err, errWSProtocol)
+	_, _, err = readFrame(frame(0x80|wsOpText, 127, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff), 10)
+	assert.ErrorIs(t, err, io.EOF)
+
+	// data frames over the limit are discarded
+	r := bytes.NewReader(append([]byte{0x80 | wsOpText, 20}, append(bytes.Repeat([]byte("x"), 20), 0x80|wsOpText, 2, 'h', 'i')...))
+	op, payload, err := readFrame(r, 10)
+	require.NoError(t, err)
+	assert.Equal(t, byte(wsOpText), op)
+	assert.Nil(t, payload)
+	_, payload, err = readFrame(r, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "hi", string(payload))
+}
@@ -0,0 +1,46 @@
+package dabug
+
+import "time"
+
+// window is the time span set by EnableBetween, a zero bound is open.
+type window struct {
+	start, end time.Time
+}
+
+// EnableFor limits output to the next d, ie: for 30 seconds after a
+// trigger. Lines are dropped once the window has passed.
+func EnableFor(d time.Duration) {
+	defDabugger.EnableFor(d)
+}
+
+func (d *Dabugger) EnableFor(dur time.Duration) {
+	now := time.Now()
+	d.EnableBetween(now, now.Add(dur))
+}
+
+// EnableBetween limits output to lines written from start up to end, a zero
+// start or end leaves that side of the window open. Call it with two zero
+// times to remove the window.
+func EnableBetween(start, end time.Time) {
+	defDabugger.EnableBetween(start, end)
+}
+
+func (d *Dabugger) EnableBetween(start, end time.Time) {
+	if start.IsZero() && end.IsZero() {
+		d.window.Store(nil)
+		return
+	}
+	d.window.Store(&window{start: start, end: end})
+}
+
+// inWindow reports whether the current time is within the EnableBetween
+// window.
+func (d *Dabugger) inWindow() bool {
+	w := d.window.Load()
+	if w == nil {
+		return true
+	}
+
+	now := time.Now()
+	return (w.start.IsZero() || !now.Before(w.start)) && (w.end.IsZero() || now.Before(w.end))
+}
@@ -0,0 +1,46 @@
+package dabug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnableFor(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+
+	d.EnableFor(50 * time.Millisecond)
+	d.Msg("inside")
+	time.Sleep(60 * time.Millisecond)
+	assert.False(t, d.Enabled())
+	d.Msg("after")
+
+	d.EnableBetween(time.Time{}, time.Time{})
+	d.Msg("always")
+
+	lines := rec.Lines()
+	require.Len(t, lines, 2)
+	assert.Equal(t, "inside", lines[0].Msg)
+	assert.Equal(t, "always", lines[1].Msg)
+}
+
+func TestEnableBetween(t *testing.T) {
+	d := New()
+	now := time.Now()
+
+	d.EnableBetween(now.Add(time.Hour), now.Add(2*time.Hour))
+	assert.False(t, d.Enabled())
+
+	d.EnableBetween(now.Add(-time.Hour), time.Time{})
+	assert.True(t, d.Enabled())
+
+	d.EnableBetween(time.Time{}, now.Add(-time.Second))
+	assert.False(t, d.Enabled())
+
+	d.EnableBetween(now.Add(-time.Hour), now.Add(time.Hour))
+	assert.True(t, d.Enabled())
+}
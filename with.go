@@ -0,0 +1,20 @@
+package dabug
+
+import "slices"
+
+// With returns a Dabugger that shares the output of the default Dabugger and
+// adds the key/value pair to the context of every line it writes. Unlike
+// AddContext the pair is not visible to other Dabuggers, so a request scoped
+// Dabugger can be passed down a call tree, ie:
+//
+//	d := dabug.With("request", id)
+//	handle(d, req)
+func With(key, value string) *Dabugger {
+	return defDabugger.With(key, value)
+}
+
+func (d *Dabugger) With(key, value string) *Dabugger {
+	child := d.derive()
+	child.with = append(slices.Clip(d.with), Field{key, value})
+	return child
+}
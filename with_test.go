@@ -0,0 +1,34 @@
+package dabug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWith(t *testing.T) {
+	rec := NewRecorder()
+	d := New()
+	d.Writer(rec)
+	d.AddContext("shared", "1")
+
+	req := d.With("request", "a")
+	user := req.With("user", "bob")
+	other := req.With("user", "eve")
+
+	d.Msg("root")
+	req.Msg("request")
+	user.Msg("user")
+	other.Msg("other")
+	req.Msg("request again")
+
+	lines := rec.Lines()
+	require.Len(t, lines, 5)
+	assert.Equal(t, Fields{{"shared", "1"}}, lines[0].Context)
+	assert.Equal(t, Fields{{"shared", "1"}, {"request", "a"}}, lines[1].Context)
+	assert.Equal(t, Fields{{"shared", "1"}, {"request", "a"}, {"user", "bob"}}, lines[2].Context)
+	assert.Equal(t, Fields{{"shared", "1"}, {"request", "a"}, {"user", "eve"}}, lines[3].Context)
+	assert.Equal(t, Fields{{"shared", "1"}, {"request", "a"}}, lines[4].Context)
+	assert.Empty(t, d.with)
+}
@@ -0,0 +1,89 @@
+package dabug
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// minWrapWidth is the narrowest a message will be wrapped to regardless of
+// how wide the line header is.
+const minWrapWidth = 20
+
+// textOpts are the settings used to render the default text format.
+type textOpts struct {
+	color    bool
+	maxWidth int
+}
+
+func (d *Dabugger) textOpts(w io.Writer) textOpts {
+	return textOpts{
+		color:    d.useColor(w),
+		maxWidth: d.maxWidth,
+	}
+}
+
+// MaxWidth soft wraps messages so that lines do not exceed cols columns,
+// wrapped lines are continued with a hanging indent. Set cols to 0 to
+// disable wrapping.
+func MaxWidth(cols int) {
+	defDabugger.MaxWidth(cols)
+}
+
+func (d *Dabugger) MaxWidth(cols int) {
+	d.maxWidth = max(cols, 0)
+}
+
+// wrap splits the message of l into the lines to render, including the
+// nesting indent. msgCol is the column the message starts at.
+func (o textOpts) wrap(l *Line, msgCol int) []string {
+	pre := strings.Repeat(indent, l.Depth)
+
+	var lines []string
+	for _, m := range strings.Split(l.Msg, "\n") {
+		if o.maxWidth == 0 {
+			lines = append(lines, pre+m)
+			continue
+		}
+
+		avail := max(o.maxWidth-msgCol-len(pre), minWrapWidth)
+		for i, w := range wrapText(m, avail, avail-len(indent)) {
+			if i > 0 {
+				w = indent + w
+			}
+			lines = append(lines, pre+w)
+		}
+	}
+
+	return lines
+}
+
+// wrapText breaks s at spaces into lines no longer than first for the
+// first line and rest for the others, words that do not fit are split.
+func wrapText(s string, first, rest int) []string {
+	var lines []string
+	limit := first
+	for utf8.RuneCountInString(s) > limit {
+		cut := runeOffset(s, limit)
+		if sp := strings.LastIndexByte(s[:cut+1], ' '); sp > 0 {
+			cut = sp
+		}
+
+		lines = append(lines, strings.TrimRight(s[:cut], " "))
+		s = strings.TrimLeft(s[cut:], " ")
+		limit = rest
+	}
+
+	return append(lines, s)
+}
+
+// runeOffset returns the byte offset of the n-th rune in s.
+func runeOffset(s string, n int) int {
+	for i := range s {
+		if n == 0 {
+			return i
+		}
+		n--
+	}
+	return len(s)
+}
@@ -0,0 +1,40 @@
+package dabug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapText(t *testing.T) {
+	assert.Equal(t, []string{"short"}, wrapText("short", 10, 8))
+	assert.Equal(t, []string{"the quick", "brown", "fox", "jumps"}, wrapText("the quick brown fox jumps", 10, 6))
+	assert.Equal(t, []string{"abcdef", "ghij", "kl"}, wrapText("abcdefghijkl", 6, 4))
+	assert.Equal(t, []string{"héllo", "wörld"}, wrapText("héllo wörld", 5, 5))
+}
+
+func TestMaxWidth(t *testing.T) {
+	sb := &strings.Builder{}
+	d := New()
+	d.Writer(sb)
+	d.LinePrefix("")
+	d.AutoFlush(false)
+	d.MaxWidth(60)
+	d.Msg(strings.Repeat("word ", 20))
+	d.Msg("short")
+	d.Flush()
+
+	parts := strings.Split(sb.String(), "\n")
+	require.Greater(t, len(parts), 5)
+
+	msgCol := strings.Index(parts[1], "- word")
+	for _, p := range parts[1 : len(parts)-3] {
+		assert.LessOrEqual(t, len(p), 60, p)
+	}
+	for _, p := range parts[2 : len(parts)-3] {
+		assert.Equal(t, msgCol, strings.Index(p, "|   word"), p)
+	}
+	assert.True(t, strings.HasSuffix(parts[len(parts)-3], "- short"))
+}
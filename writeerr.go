@@ -0,0 +1,29 @@
+package dabug
+
+// OnWriteError sets a func that is called with every error returned by a
+// writer. It may be called while lines are being flushed, so it must not
+// call back into the same Dabugger.
+func OnWriteError(fn func(err error)) {
+	defDabugger.OnWriteError(fn)
+}
+
+func (d *Dabugger) OnWriteError(fn func(err error)) {
+	d.onWriteError = fn
+}
+
+// Dropped returns the number of lines that failed to be written, a line
+// counts once for every writer it failed on.
+func Dropped() uint64 {
+	return defDabugger.Dropped()
+}
+
+func (d *Dabugger) Dropped() uint64 {
+	return d.dropped.Load()
+}
+
+func (d *Dabugger) writeFailed(err error, lines int) {
+	d.dropped.Add(uint64(lines))
+	if d.onWriteError != nil {
+		d.onWriteError(err)
+	}
+}
@@ -0,0 +1,43 @@
+package dabug
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failLineWriter struct {
+	failWriter
+}
+
+func (failLineWriter) WriteLine(l Line) error {
+	return errors.New("line fail")
+}
+
+func TestOnWriteError(t *testing.T) {
+	var errs []error
+
+	d := New()
+	d.Writer(&strings.Builder{})
+	d.AddWriter(failWriter{})
+	d.OnWriteError(func(err error) { errs = append(errs, err) })
+
+	d.Msg("one")
+	assert.Equal(t, uint64(1), d.Dropped())
+
+	d.AutoFlush(false)
+	d.Msg("two")
+	d.Msg("three")
+	d.Flush()
+	assert.Equal(t, uint64(3), d.Dropped())
+
+	d.Writer(failLineWriter{})
+	d.Msg("four")
+	d.Flush()
+	assert.Equal(t, uint64(4), d.Dropped())
+
+	assert.Len(t, errs, 3)
+	assert.EqualError(t, errs[2], "line fail")
+}